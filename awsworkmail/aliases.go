@@ -0,0 +1,244 @@
+package awsworkmail
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/workmail"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// aliasDomain returns the domain part of an email address, or an empty string
+// if alias is not of the form local@domain.
+func aliasDomain(alias string) string {
+	at := strings.LastIndex(alias, "@")
+	if at <= 0 || at == len(alias)-1 || strings.Count(alias, "@") != 1 {
+		return ""
+	}
+	return strings.ToLower(alias[at+1:])
+}
+
+// validateAliasesConfig checks the syntax of the configured aliases and that
+// none of them is the primary address.
+func validateAliasesConfig(ctx context.Context, aliases types.Set, email types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if aliases.IsNull() || aliases.IsUnknown() {
+		return diags
+	}
+
+	var values []types.String
+	diags.Append(aliases.ElementsAs(ctx, &values, false)...)
+	for _, v := range values {
+		if v.IsUnknown() {
+			continue
+		}
+		if aliasDomain(v.ValueString()) == "" {
+			diags.AddAttributeError(path.Root("aliases"), "Invalid alias", fmt.Sprintf("%q is not an email address.", v.ValueString()))
+			continue
+		}
+		if !email.IsNull() && !email.IsUnknown() && strings.EqualFold(v.ValueString(), email.ValueString()) {
+			diags.AddAttributeError(path.Root("aliases"), "Invalid alias", fmt.Sprintf("%q is the primary email address and cannot also be an alias.", v.ValueString()))
+		}
+	}
+	return diags
+}
+
+// unregisteredAliasDomains returns the aliases whose domain is not registered
+// with the organization.
+func unregisteredAliasDomains(ctx context.Context, client *workmail.Client, organizationID string, aliases []string) ([]string, error) {
+	if len(aliases) == 0 {
+		return nil, nil
+	}
+	domains, err := listMailDomains(ctx, client, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	registered := map[string]bool{}
+	for _, d := range domains {
+		registered[strings.ToLower(aws.ToString(d.DomainName))] = true
+	}
+
+	var missing []string
+	for _, alias := range aliases {
+		if !registered[aliasDomain(alias)] {
+			missing = append(missing, alias)
+		}
+	}
+	return missing, nil
+}
+
+// readEntityAliases returns the aliases of the entity, without its primary
+// address, which ListAliases also reports.
+func readEntityAliases(ctx context.Context, client *workmail.Client, organizationID, entityID, email string) ([]string, error) {
+	result := []string{}
+	aliases, err := listAliases(ctx, client, organizationID, entityID)
+	if err != nil {
+		// Disabled entities have no addresses.
+		if isEntityStateException(err) {
+			return result, nil
+		}
+		return nil, err
+	}
+	for _, alias := range aliases {
+		if !strings.EqualFold(alias, email) {
+			result = append(result, alias)
+		}
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+// diffAliases returns the aliases to create and to delete so that current
+// matches desired. Addresses are compared case-insensitively.
+func diffAliases(current, desired []string) (create, remove []string) {
+	have := map[string]bool{}
+	for _, a := range current {
+		have[strings.ToLower(a)] = true
+	}
+	want := map[string]bool{}
+	for _, a := range desired {
+		want[strings.ToLower(a)] = true
+		if !have[strings.ToLower(a)] {
+			create = append(create, a)
+		}
+	}
+	for _, a := range current {
+		if !want[strings.ToLower(a)] {
+			remove = append(remove, a)
+		}
+	}
+	return create, remove
+}
+
+// reconcileAliases makes the aliases of the entity match desired, and returns
+// the resulting aliases.
+func reconcileAliases(ctx context.Context, client *workmail.Client, organizationID, entityID, email string, desired []string) ([]string, error) {
+	current, err := readEntityAliases(ctx, client, organizationID, entityID, email)
+	if err != nil {
+		return nil, fmt.Errorf("listing aliases: %w", err)
+	}
+	create, remove := diffAliases(current, desired)
+
+	missing, err := unregisteredAliasDomains(ctx, client, organizationID, create)
+	if err != nil {
+		return nil, fmt.Errorf("listing mail domains: %w", err)
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("the domain of %s is not registered with organization %s", strings.Join(missing, ", "), organizationID)
+	}
+
+	for _, alias := range remove {
+		_, err := client.DeleteAlias(ctx, &workmail.DeleteAliasInput{
+			OrganizationId: aws.String(organizationID),
+			EntityId:       aws.String(entityID),
+			Alias:          aws.String(alias),
+		})
+		if err != nil && !isEntityNotFound(err) {
+			return nil, fmt.Errorf("deleting alias %s: %w", alias, err)
+		}
+	}
+	for _, alias := range create {
+		_, err := client.CreateAlias(ctx, &workmail.CreateAliasInput{
+			OrganizationId: aws.String(organizationID),
+			EntityId:       aws.String(entityID),
+			Alias:          aws.String(alias),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("creating alias %s: %w", alias, err)
+		}
+	}
+
+	return readEntityAliases(ctx, client, organizationID, entityID, email)
+}
+
+// setEntityAliases reconciles the aliases of the entity when they are set in
+// the configuration, and otherwise only reads them. The plan cannot be used
+// for this, since it carries the aliases over from state when they are not
+// configured.
+func setEntityAliases(ctx context.Context, client *workmail.Client, config tfsdk.Config, organizationID, entityID, email string) (types.Set, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var configured types.Set
+	diags.Append(config.GetAttribute(ctx, path.Root("aliases"), &configured)...)
+	if diags.HasError() {
+		return types.SetNull(types.StringType), diags
+	}
+
+	var aliases []string
+	var err error
+	if configured.IsNull() || configured.IsUnknown() {
+		aliases, err = readEntityAliases(ctx, client, organizationID, entityID, email)
+	} else {
+		var desired []string
+		diags.Append(configured.ElementsAs(ctx, &desired, false)...)
+		if diags.HasError() {
+			return types.SetNull(types.StringType), diags
+		}
+		aliases, err = reconcileAliases(ctx, client, organizationID, entityID, email, desired)
+	}
+	if err != nil {
+		diags.AddError("Error managing WorkMail aliases", err.Error())
+		return types.SetNull(types.StringType), diags
+	}
+
+	set, d := types.SetValueFrom(ctx, types.StringType, aliases)
+	diags.Append(d...)
+	return set, diags
+}
+
+// warnUnregisteredAliasDomains adds a plan-time warning for configured aliases
+// on domains that are not registered yet. Domains registered in the same run
+// are fine, so this is not an error; the apply performs the authoritative
+// check.
+func warnUnregisteredAliasDomains(ctx context.Context, client *workmail.Client, organizationID types.String, aliases types.Set) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if organizationID.IsUnknown() || organizationID.IsNull() || aliases.IsUnknown() || aliases.IsNull() {
+		return diags
+	}
+
+	var elements []types.String
+	diags.Append(aliases.ElementsAs(ctx, &elements, false)...)
+	if diags.HasError() {
+		return diags
+	}
+	var values []string
+	for _, v := range elements {
+		if !v.IsUnknown() {
+			values = append(values, v.ValueString())
+		}
+	}
+	missing, err := unregisteredAliasDomains(ctx, client, organizationID.ValueString(), values)
+	if err != nil {
+		// The organization may not exist yet; the apply checks again.
+		return diags
+	}
+	if len(missing) > 0 {
+		diags.AddAttributeWarning(
+			path.Root("aliases"),
+			"Alias domain not registered",
+			fmt.Sprintf("The domain of %s is not registered with organization %s. The apply will fail unless the domain is registered first.", strings.Join(missing, ", "), organizationID.ValueString()),
+		)
+	}
+	return diags
+}
+
+// planAliasesAfterAddressChange marks aliases unknown in the plan when they
+// are not configured. Callers use it when the primary address or the
+// registration of the entity changes: WorkMail may then keep the previous
+// address as an alias or drop aliases, so the value carried over from state
+// would not match the result of the apply.
+func planAliasesAfterAddressChange(ctx context.Context, config tfsdk.Config, plan *tfsdk.Plan) diag.Diagnostics {
+	var aliases types.Set
+	diags := config.GetAttribute(ctx, path.Root("aliases"), &aliases)
+	if diags.HasError() || !aliases.IsNull() {
+		return diags
+	}
+	diags.Append(plan.SetAttribute(ctx, path.Root("aliases"), types.SetUnknown(types.StringType))...)
+	return diags
+}
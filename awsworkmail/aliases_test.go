@@ -0,0 +1,34 @@
+package awsworkmail
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAliasDomain(t *testing.T) {
+	cases := map[string]string{
+		"sales@Example.com": "example.com",
+		"sales@":            "",
+		"@example.com":      "",
+		"sales":             "",
+		"a@b@example.com":   "",
+	}
+	for alias, want := range cases {
+		if got := aliasDomain(alias); got != want {
+			t.Errorf("aliasDomain(%q) = %q, want %q", alias, got, want)
+		}
+	}
+}
+
+func TestDiffAliases(t *testing.T) {
+	create, remove := diffAliases(
+		[]string{"info@example.com", "old@example.com"},
+		[]string{"INFO@example.com", "sales@example.com"},
+	)
+	if !reflect.DeepEqual(create, []string{"sales@example.com"}) {
+		t.Errorf("create = %v", create)
+	}
+	if !reflect.DeepEqual(remove, []string{"old@example.com"}) {
+		t.Errorf("remove = %v", remove)
+	}
+}
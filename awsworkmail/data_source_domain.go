@@ -0,0 +1,153 @@
+package awsworkmail
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/workmail"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure implementation satisfies the expected interfaces.
+var _ datasource.DataSource = &domainDataSource{}
+
+// domainDataSource is the data source implementation.
+type domainDataSource struct {
+	cfg aws.Config
+}
+
+func NewDomainDataSource() datasource.DataSource {
+	return &domainDataSource{}
+}
+
+type domainDataSourceModel struct {
+	OrganizationID              types.String `tfsdk:"organization_id"`
+	Domain                      types.String `tfsdk:"domain"`
+	Default                     types.Bool   `tfsdk:"default"`
+	TestDomain                  types.Bool   `tfsdk:"test_domain"`
+	OwnershipVerificationStatus types.String `tfsdk:"ownership_verification_status"`
+	DkimVerificationStatus      types.String `tfsdk:"dkim_verification_status"`
+	MXRecords                   types.List   `tfsdk:"mx_records"`
+	Records                     types.List   `tfsdk:"records"`
+}
+
+func (d *domainDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_domain"
+}
+
+func (d *domainDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Data source for querying a mail domain registered with an AWS WorkMail organization.",
+		Attributes: map[string]schema.Attribute{
+			"organization_id": schema.StringAttribute{
+				Description: "The WorkMail Organization ID.",
+				Required:    true,
+			},
+			"domain": schema.StringAttribute{
+				Description: "The mail domain name.",
+				Required:    true,
+			},
+			"default": schema.BoolAttribute{
+				Description: "Whether this is the default mail domain of the organization.",
+				Computed:    true,
+			},
+			"test_domain": schema.BoolAttribute{
+				Description: "Whether this is the awsapps.com test domain of the organization.",
+				Computed:    true,
+			},
+			"ownership_verification_status": schema.StringAttribute{
+				Description: "Status of the domain ownership verification.",
+				Computed:    true,
+			},
+			"dkim_verification_status": schema.StringAttribute{
+				Description: "Status of the DKIM verification.",
+				Computed:    true,
+			},
+			"mx_records": schema.ListAttribute{
+				Description: "The MX record values to configure in DNS for this domain.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"records": dnsRecordsDataSourceAttribute(),
+		},
+	}
+}
+
+// dnsRecordsDataSourceAttribute returns the schema of the records attribute
+// shared by the domain data sources.
+func dnsRecordsDataSourceAttribute() schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		Description: "All DNS records to configure for the domain.",
+		Computed:    true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"type": schema.StringAttribute{
+					Description: "The DNS record type.",
+					Computed:    true,
+				},
+				"hostname": schema.StringAttribute{
+					Description: "The fully qualified name of the DNS record.",
+					Computed:    true,
+				},
+				"value": schema.StringAttribute{
+					Description: "The value of the DNS record.",
+					Computed:    true,
+				},
+			},
+		},
+	}
+}
+
+func (d *domainDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	pd, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.cfg = pd.cfg
+}
+
+func (d *domainDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data domainDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := workmail.NewFromConfig(d.cfg)
+
+	output, err := client.GetMailDomain(ctx, &workmail.GetMailDomainInput{
+		OrganizationId: aws.String(data.OrganizationID.ValueString()),
+		DomainName:     aws.String(data.Domain.ValueString()),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to get WorkMail mail domain", err.Error())
+		return
+	}
+
+	// Reuse the resource model conversion for records and statuses.
+	var details domainResourceModel
+	resp.Diagnostics.Append(setDomainDetails(ctx, &details, output)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Default = types.BoolValue(output.IsDefault)
+	data.TestDomain = types.BoolValue(output.IsTestDomain)
+	data.OwnershipVerificationStatus = details.OwnershipVerificationStatus
+	data.DkimVerificationStatus = details.DkimVerificationStatus
+	data.MXRecords = details.MXRecords
+	data.Records = details.Records
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,342 @@
+package awsworkmail
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/workmail"
+	wmtypes "github.com/aws/aws-sdk-go-v2/service/workmail/types"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                   = &domainDNSCheckDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &domainDNSCheckDataSource{}
+)
+
+// domainDNSCheckDataSource is the data source implementation.
+type domainDNSCheckDataSource struct {
+	cfg aws.Config
+}
+
+func NewDomainDNSCheckDataSource() datasource.DataSource {
+	return &domainDNSCheckDataSource{}
+}
+
+type domainDNSCheckDataSourceModel struct {
+	OrganizationID types.String `tfsdk:"organization_id"`
+	Domain         types.String `tfsdk:"domain"`
+	Resolver       types.String `tfsdk:"resolver"`
+	Conformant     types.Bool   `tfsdk:"conformant"`
+	Records        types.List   `tfsdk:"records"`
+}
+
+// dnsCheckAttrTypes describes an element of the records attribute.
+var dnsCheckAttrTypes = map[string]attr.Type{
+	"type":          types.StringType,
+	"hostname":      types.StringType,
+	"value":         types.StringType,
+	"actual_values": types.ListType{ElemType: types.StringType},
+	"matches":       types.BoolType,
+	"error":         types.StringType,
+}
+
+func (d *domainDNSCheckDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_domain_dns_check"
+}
+
+func (d *domainDNSCheckDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Data source that checks whether public DNS matches the MX, TXT and CNAME records WorkMail expects for a registered mail domain.",
+		Attributes: map[string]schema.Attribute{
+			"organization_id": schema.StringAttribute{
+				Description: "The WorkMail Organization ID.",
+				Required:    true,
+			},
+			"domain": schema.StringAttribute{
+				Description: "The registered mail domain to check.",
+				Required:    true,
+			},
+			"resolver": schema.StringAttribute{
+				Description: "Address of the DNS resolver to query, as host or host:port (port defaults to 53). If not set, the system resolver is used.",
+				Optional:    true,
+			},
+			"conformant": schema.BoolAttribute{
+				Description: "Whether every checked record matches what WorkMail expects.",
+				Computed:    true,
+			},
+			"records": schema.ListNestedAttribute{
+				Description: "The result of the check for each MX, TXT and CNAME record returned by WorkMail.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							Description: "The DNS record type.",
+							Computed:    true,
+						},
+						"hostname": schema.StringAttribute{
+							Description: "The fully qualified name of the DNS record.",
+							Computed:    true,
+						},
+						"value": schema.StringAttribute{
+							Description: "The value WorkMail expects.",
+							Computed:    true,
+						},
+						"actual_values": schema.ListAttribute{
+							Description: "The values found in DNS. For CNAME records this is the canonical name the hostname resolves to.",
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+						"matches": schema.BoolAttribute{
+							Description: "Whether DNS contains the expected value.",
+							Computed:    true,
+						},
+						"error": schema.StringAttribute{
+							Description: "The lookup error, if the query failed.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *domainDNSCheckDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	pd, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.cfg = pd.cfg
+}
+
+func (d *domainDNSCheckDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var resolver types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("resolver"), &resolver)...)
+	if resp.Diagnostics.HasError() || resolver.IsNull() || resolver.IsUnknown() {
+		return
+	}
+	if _, err := resolverAddress(resolver.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("resolver"), "Invalid resolver", err.Error())
+	}
+}
+
+func (d *domainDNSCheckDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data domainDNSCheckDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resolver := net.DefaultResolver
+	if !data.Resolver.IsNull() {
+		address, err := resolverAddress(data.Resolver.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("resolver"), "Invalid resolver", err.Error())
+			return
+		}
+		resolver = newResolver(address)
+	}
+
+	client := workmail.NewFromConfig(d.cfg)
+
+	output, err := client.GetMailDomain(ctx, &workmail.GetMailDomainInput{
+		OrganizationId: aws.String(data.OrganizationID.ValueString()),
+		DomainName:     aws.String(data.Domain.ValueString()),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to get WorkMail mail domain", err.Error())
+		return
+	}
+
+	checks := checkDNSRecords(ctx, resolver, output.Records)
+
+	conformant := true
+	values := make([]attr.Value, 0, len(checks))
+	for _, c := range checks {
+		conformant = conformant && c.matches
+		actual, diags := types.ListValueFrom(ctx, types.StringType, c.actual)
+		resp.Diagnostics.Append(diags...)
+		lookupErr := types.StringNull()
+		if c.err != "" {
+			lookupErr = types.StringValue(c.err)
+		}
+		obj, diags := types.ObjectValue(dnsCheckAttrTypes, map[string]attr.Value{
+			"type":          types.StringValue(c.recordType),
+			"hostname":      types.StringValue(c.hostname),
+			"value":         types.StringValue(c.value),
+			"actual_values": actual,
+			"matches":       types.BoolValue(c.matches),
+			"error":         lookupErr,
+		})
+		resp.Diagnostics.Append(diags...)
+		values = append(values, obj)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	records, diags := types.ListValue(types.ObjectType{AttrTypes: dnsCheckAttrTypes}, values)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Conformant = types.BoolValue(conformant)
+	data.Records = records
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// dnsRecordCheck is the result of checking one expected record against DNS.
+type dnsRecordCheck struct {
+	recordType string
+	hostname   string
+	value      string
+	actual     []string
+	matches    bool
+	err        string
+}
+
+// resolverAddress normalizes a resolver given as host or host:port.
+func resolverAddress(resolver string) (string, error) {
+	if resolver == "" {
+		return "", fmt.Errorf("resolver must not be empty")
+	}
+	if _, _, err := net.SplitHostPort(resolver); err == nil {
+		return resolver, nil
+	}
+	host := strings.TrimSuffix(strings.TrimPrefix(resolver, "["), "]")
+	if strings.ContainsAny(host, "[]") {
+		return "", fmt.Errorf("invalid resolver address %q", resolver)
+	}
+	return net.JoinHostPort(host, "53"), nil
+}
+
+// newResolver returns a resolver that sends every query to address.
+func newResolver(address string) *net.Resolver {
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, network, address)
+		},
+	}
+}
+
+// checkDNSRecords looks up every MX, TXT and CNAME record WorkMail expects and
+// reports whether DNS contains the expected value. Other record types are
+// skipped.
+func checkDNSRecords(ctx context.Context, resolver *net.Resolver, records []wmtypes.DnsRecord) []dnsRecordCheck {
+	checks := []dnsRecordCheck{}
+	for _, record := range records {
+		c := dnsRecordCheck{
+			recordType: strings.ToUpper(aws.ToString(record.Type)),
+			hostname:   aws.ToString(record.Hostname),
+			value:      aws.ToString(record.Value),
+			actual:     []string{},
+		}
+
+		var err error
+		switch c.recordType {
+		case "MX":
+			err = checkMXRecord(ctx, resolver, &c)
+		case "TXT":
+			err = checkTXTRecord(ctx, resolver, &c)
+		case "CNAME":
+			err = checkCNAMERecord(ctx, resolver, &c)
+		default:
+			continue
+		}
+		if err != nil {
+			c.err = err.Error()
+		}
+		checks = append(checks, c)
+	}
+	return checks
+}
+
+func checkMXRecord(ctx context.Context, resolver *net.Resolver, c *dnsRecordCheck) error {
+	mxs, err := resolver.LookupMX(ctx, c.hostname)
+	if err != nil {
+		return err
+	}
+	want := normalizeMX(c.value)
+	for _, mx := range mxs {
+		got := normalizeMX(strconv.Itoa(int(mx.Pref)) + " " + mx.Host)
+		c.actual = append(c.actual, got)
+		c.matches = c.matches || got == want
+	}
+	return nil
+}
+
+func checkTXTRecord(ctx context.Context, resolver *net.Resolver, c *dnsRecordCheck) error {
+	txts, err := resolver.LookupTXT(ctx, c.hostname)
+	if err != nil {
+		return err
+	}
+	want := strings.Trim(c.value, `"`)
+	for _, txt := range txts {
+		c.actual = append(c.actual, txt)
+		c.matches = c.matches || txt == want
+	}
+	return nil
+}
+
+// checkCNAMERecord compares the canonical names of the hostname and of the
+// expected target, so that a target which is itself an alias still matches.
+func checkCNAMERecord(ctx context.Context, resolver *net.Resolver, c *dnsRecordCheck) error {
+	got, err := resolver.LookupCNAME(ctx, c.hostname)
+	if err != nil {
+		return err
+	}
+	got = normalizeHostname(got)
+	c.actual = append(c.actual, got)
+
+	want := normalizeHostname(c.value)
+	if got == want {
+		c.matches = true
+		return nil
+	}
+	// Only the hostname itself is authoritative; if the target cannot be
+	// resolved, report a mismatch rather than an error.
+	if target, err := resolver.LookupCNAME(ctx, want); err == nil && normalizeHostname(target) == got && got != normalizeHostname(c.hostname) {
+		c.matches = true
+	}
+	return nil
+}
+
+// normalizeHostname lowercases name and ensures a trailing dot.
+func normalizeHostname(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if !strings.HasSuffix(name, ".") {
+		name += "."
+	}
+	return name
+}
+
+// normalizeMX renders an MX value as "<preference> <host>." in lowercase.
+func normalizeMX(value string) string {
+	fields := strings.Fields(value)
+	if len(fields) != 2 {
+		return strings.ToLower(strings.TrimSpace(value))
+	}
+	return fields[0] + " " + normalizeHostname(fields[1])
+}
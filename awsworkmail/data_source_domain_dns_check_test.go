@@ -0,0 +1,223 @@
+package awsworkmail
+
+import (
+	"context"
+	"net"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	wmtypes "github.com/aws/aws-sdk-go-v2/service/workmail/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// testDNSZone is the data served by the local DNS stand-in.
+type testDNSZone struct {
+	cname map[string]string
+	a     map[string]string
+	mx    map[string][]dnsmessage.MXResource
+	txt   map[string][]string
+}
+
+// startTestDNSServer serves zone over UDP on a random local port and returns
+// its address.
+func startTestDNSServer(t *testing.T, zone testDNSZone) string {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			var req dnsmessage.Message
+			if err := req.Unpack(buf[:n]); err != nil || len(req.Questions) != 1 {
+				continue
+			}
+			out, err := zone.answer(req)
+			if err != nil {
+				continue
+			}
+			_, _ = conn.WriteTo(out, addr)
+		}
+	}()
+
+	return conn.LocalAddr().String()
+}
+
+func (z testDNSZone) answer(req dnsmessage.Message) ([]byte, error) {
+	q := req.Questions[0]
+	resp := dnsmessage.Message{
+		Header:    dnsmessage.Header{ID: req.ID, Response: true, Authoritative: true, RecursionAvailable: true},
+		Questions: req.Questions,
+	}
+
+	name := strings.ToLower(q.Name.String())
+	hdr := func(n string, typ dnsmessage.Type) dnsmessage.ResourceHeader {
+		return dnsmessage.ResourceHeader{Name: dnsmessage.MustNewName(n), Type: typ, Class: dnsmessage.ClassINET, TTL: 60}
+	}
+
+	// Follow CNAMEs inside the zone, as a recursive resolver would.
+	for q.Type != dnsmessage.TypeCNAME {
+		target, ok := z.cname[name]
+		if !ok {
+			break
+		}
+		resp.Answers = append(resp.Answers, dnsmessage.Resource{Header: hdr(name, dnsmessage.TypeCNAME), Body: &dnsmessage.CNAMEResource{CNAME: dnsmessage.MustNewName(target)}})
+		name = target
+	}
+
+	found := len(resp.Answers) > 0
+	switch q.Type {
+	case dnsmessage.TypeCNAME:
+		if target, ok := z.cname[name]; ok {
+			resp.Answers = append(resp.Answers, dnsmessage.Resource{Header: hdr(name, dnsmessage.TypeCNAME), Body: &dnsmessage.CNAMEResource{CNAME: dnsmessage.MustNewName(target)}})
+			found = true
+		}
+	case dnsmessage.TypeA:
+		if ip, ok := z.a[name]; ok {
+			var a [4]byte
+			copy(a[:], net.ParseIP(ip).To4())
+			resp.Answers = append(resp.Answers, dnsmessage.Resource{Header: hdr(name, dnsmessage.TypeA), Body: &dnsmessage.AResource{A: a}})
+			found = true
+		}
+	case dnsmessage.TypeMX:
+		for _, mx := range z.mx[name] {
+			mx := mx
+			resp.Answers = append(resp.Answers, dnsmessage.Resource{Header: hdr(name, dnsmessage.TypeMX), Body: &mx})
+			found = true
+		}
+	case dnsmessage.TypeTXT:
+		if txt, ok := z.txt[name]; ok {
+			resp.Answers = append(resp.Answers, dnsmessage.Resource{Header: hdr(name, dnsmessage.TypeTXT), Body: &dnsmessage.TXTResource{TXT: txt}})
+			found = true
+		}
+	}
+	if !found && !z.known(name) {
+		resp.RCode = dnsmessage.RCodeNameError
+	}
+	return resp.Pack()
+}
+
+func (z testDNSZone) known(name string) bool {
+	_, a := z.a[name]
+	_, mx := z.mx[name]
+	_, txt := z.txt[name]
+	_, cname := z.cname[name]
+	return a || mx || txt || cname
+}
+
+func TestCheckDNSRecords(t *testing.T) {
+	addr := startTestDNSServer(t, testDNSZone{
+		cname: map[string]string{
+			"good._domainkey.example.com.":             "good.dkim.amazonses.com.",
+			"autodiscover.example.com.":                "autodiscover.mail.us-east-1.awsapps.com.",
+			"autodiscover.mail.us-east-1.awsapps.com.": "edge.awsapps.com.",
+			"bad._domainkey.example.com.":              "wrong.dkim.amazonses.com.",
+		},
+		a: map[string]string{
+			"good.dkim.amazonses.com.":  "192.0.2.1",
+			"wrong.dkim.amazonses.com.": "192.0.2.2",
+			"edge.awsapps.com.":         "192.0.2.3",
+		},
+		mx: map[string][]dnsmessage.MXResource{
+			"example.com.": {{Pref: 10, MX: dnsmessage.MustNewName("inbound-smtp.us-east-1.amazonaws.com.")}},
+		},
+		txt: map[string][]string{
+			"_amazonses.example.com.": {"token123"},
+			"example.com.":            {"v=spf1 include:amazonses.com ~all"},
+		},
+	})
+
+	resolverAddr, err := resolverAddress(addr)
+	if err != nil {
+		t.Fatalf("resolverAddress: %v", err)
+	}
+	resolver := newResolver(resolverAddr)
+
+	record := func(typ, host, value string) wmtypes.DnsRecord {
+		return wmtypes.DnsRecord{Type: aws.String(typ), Hostname: aws.String(host), Value: aws.String(value)}
+	}
+	checks := checkDNSRecords(context.Background(), resolver, []wmtypes.DnsRecord{
+		record("MX", "example.com.", "10 inbound-smtp.us-east-1.amazonaws.com."),
+		record("TXT", "_amazonses.example.com.", "token123"),
+		record("TXT", "example.com.", `"v=spf1 include:amazonses.com ~all"`),
+		record("CNAME", "good._domainkey.example.com.", "good.dkim.amazonses.com."),
+		record("CNAME", "autodiscover.example.com.", "autodiscover.mail.us-east-1.awsapps.com."),
+		record("CNAME", "bad._domainkey.example.com.", "bad.dkim.amazonses.com."),
+		record("CNAME", "missing._domainkey.example.com.", "missing.dkim.amazonses.com."),
+		record("A", "example.com.", "192.0.2.10"),
+	})
+
+	want := map[string]bool{
+		"MX example.com.":                       true,
+		"TXT _amazonses.example.com.":           true,
+		"TXT example.com.":                      true,
+		"CNAME good._domainkey.example.com.":    true,
+		"CNAME autodiscover.example.com.":       true,
+		"CNAME bad._domainkey.example.com.":     false,
+		"CNAME missing._domainkey.example.com.": false,
+	}
+	if len(checks) != len(want) {
+		t.Fatalf("checkDNSRecords() returned %d checks, want %d", len(checks), len(want))
+	}
+	for _, c := range checks {
+		key := c.recordType + " " + c.hostname
+		if c.matches != want[key] {
+			t.Errorf("%s: matches = %v, want %v (actual %v, error %q)", key, c.matches, want[key], c.actual, c.err)
+		}
+	}
+}
+
+func TestResolverAddress(t *testing.T) {
+	cases := map[string]string{
+		"127.0.0.1":      "127.0.0.1:53",
+		"127.0.0.1:5353": "127.0.0.1:5353",
+		"::1":            "[::1]:53",
+		"[::1]:5353":     "[::1]:5353",
+		"dns.example":    "dns.example:53",
+	}
+	for in, want := range cases {
+		got, err := resolverAddress(in)
+		if err != nil || got != want {
+			t.Errorf("resolverAddress(%q) = %q, %v, want %q", in, got, err, want)
+		}
+	}
+	if _, err := resolverAddress(""); err == nil {
+		t.Error("resolverAddress(\"\") should fail")
+	}
+}
+
+func TestAccDataSourceDomainDNSCheck_basic(t *testing.T) {
+	orgID := os.Getenv("WORKMAIL_ORGANIZATION_ID")
+	domain := os.Getenv("TF_AWSWORKMAIL_VERIFIED_DOMAIN")
+	if orgID == "" || domain == "" {
+		t.Skip("WORKMAIL_ORGANIZATION_ID and TF_AWSWORKMAIL_VERIFIED_DOMAIN must be set for acceptance tests")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{{
+			Config: `
+data "awsworkmail_domain_dns_check" "test" {
+  organization_id = "` + orgID + `"
+  domain          = "` + domain + `"
+}
+`,
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("data.awsworkmail_domain_dns_check.test", "conformant", "true"),
+				resource.TestCheckResourceAttrSet("data.awsworkmail_domain_dns_check.test", "records.#"),
+			),
+		}},
+	})
+}
@@ -0,0 +1,194 @@
+package awsworkmail
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/workmail"
+	wmtypes "github.com/aws/aws-sdk-go-v2/service/workmail/types"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure implementation satisfies the expected interfaces.
+var _ datasource.DataSource = &domainsDataSource{}
+
+// domainsDataSource is the data source implementation.
+type domainsDataSource struct {
+	cfg aws.Config
+}
+
+func NewDomainsDataSource() datasource.DataSource {
+	return &domainsDataSource{}
+}
+
+type domainsDataSourceModel struct {
+	OrganizationID    types.String `tfsdk:"organization_id"`
+	DefaultMailDomain types.String `tfsdk:"default_mail_domain"`
+	Names             types.List   `tfsdk:"names"`
+	Domains           types.List   `tfsdk:"domains"`
+}
+
+// mailDomainAttrTypes describes an element of the domains attribute.
+var mailDomainAttrTypes = map[string]attr.Type{
+	"domain_name":                   types.StringType,
+	"default":                       types.BoolType,
+	"test_domain":                   types.BoolType,
+	"ownership_verification_status": types.StringType,
+	"dkim_verification_status":      types.StringType,
+	"records":                       types.ListType{ElemType: types.ObjectType{AttrTypes: dnsRecordAttrTypes}},
+}
+
+func (d *domainsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_domains"
+}
+
+func (d *domainsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Data source for listing the mail domains registered with an AWS WorkMail organization.",
+		Attributes: map[string]schema.Attribute{
+			"organization_id": schema.StringAttribute{
+				Description: "The WorkMail Organization ID.",
+				Required:    true,
+			},
+			"default_mail_domain": schema.StringAttribute{
+				Description: "The default mail domain of the organization.",
+				Computed:    true,
+			},
+			"names": schema.ListAttribute{
+				Description: "The names of all registered mail domains.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"domains": schema.ListNestedAttribute{
+				Description: "The registered mail domains.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"domain_name": schema.StringAttribute{
+							Description: "The mail domain name.",
+							Computed:    true,
+						},
+						"default": schema.BoolAttribute{
+							Description: "Whether this is the default mail domain of the organization.",
+							Computed:    true,
+						},
+						"test_domain": schema.BoolAttribute{
+							Description: "Whether this is the awsapps.com test domain of the organization.",
+							Computed:    true,
+						},
+						"ownership_verification_status": schema.StringAttribute{
+							Description: "Status of the domain ownership verification.",
+							Computed:    true,
+						},
+						"dkim_verification_status": schema.StringAttribute{
+							Description: "Status of the DKIM verification.",
+							Computed:    true,
+						},
+						"records": dnsRecordsDataSourceAttribute(),
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *domainsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	pd, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.cfg = pd.cfg
+}
+
+func (d *domainsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data domainsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := workmail.NewFromConfig(d.cfg)
+
+	summaries, err := listMailDomains(ctx, client, data.OrganizationID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to list WorkMail mail domains", err.Error())
+		return
+	}
+
+	names := []string{}
+	values := []attr.Value{}
+	data.DefaultMailDomain = types.StringNull()
+	for _, summary := range summaries {
+		name := aws.ToString(summary.DomainName)
+		output, err := client.GetMailDomain(ctx, &workmail.GetMailDomainInput{
+			OrganizationId: aws.String(data.OrganizationID.ValueString()),
+			DomainName:     aws.String(name),
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to get WorkMail mail domain "+name, err.Error())
+			return
+		}
+
+		records, diags := dnsRecordsListValue(output.Records)
+		resp.Diagnostics.Append(diags...)
+		obj, diags := types.ObjectValue(mailDomainAttrTypes, map[string]attr.Value{
+			"domain_name":                   types.StringValue(name),
+			"default":                       types.BoolValue(summary.DefaultDomain),
+			"test_domain":                   types.BoolValue(output.IsTestDomain),
+			"ownership_verification_status": types.StringValue(string(output.OwnershipVerificationStatus)),
+			"dkim_verification_status":      types.StringValue(string(output.DkimVerificationStatus)),
+			"records":                       records,
+		})
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if summary.DefaultDomain {
+			data.DefaultMailDomain = types.StringValue(name)
+		}
+		names = append(names, name)
+		values = append(values, obj)
+	}
+
+	nameList, diags := types.ListValueFrom(ctx, types.StringType, names)
+	resp.Diagnostics.Append(diags...)
+	domainList, diags := types.ListValue(types.ObjectType{AttrTypes: mailDomainAttrTypes}, values)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Names = nameList
+	data.Domains = domainList
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// listMailDomains returns every mail domain of the organization, following NextToken.
+func listMailDomains(ctx context.Context, client *workmail.Client, organizationID string) ([]wmtypes.MailDomainSummary, error) {
+	var domains []wmtypes.MailDomainSummary
+	paginator := workmail.NewListMailDomainsPaginator(client, &workmail.ListMailDomainsInput{
+		OrganizationId: aws.String(organizationID),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		domains = append(domains, page.MailDomains...)
+	}
+	return domains, nil
+}
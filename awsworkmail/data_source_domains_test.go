@@ -0,0 +1,45 @@
+package awsworkmail
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDataSourceDomains_basic(t *testing.T) {
+	orgID := os.Getenv("WORKMAIL_ORGANIZATION_ID")
+	if orgID == "" {
+		t.Skip("WORKMAIL_ORGANIZATION_ID must be set for acceptance tests")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{{
+			Config: testAccDataSourceDomainsConfig(orgID),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttrSet("data.awsworkmail_domains.test", "default_mail_domain"),
+				resource.TestCheckResourceAttrSet("data.awsworkmail_domains.test", "domains.0.domain_name"),
+				resource.TestCheckResourceAttrSet("data.awsworkmail_domains.test", "domains.0.records.#"),
+				resource.TestCheckResourceAttrPair("data.awsworkmail_domain.test", "domain", "data.awsworkmail_domains.test", "default_mail_domain"),
+				resource.TestCheckResourceAttr("data.awsworkmail_domain.test", "default", "true"),
+				resource.TestCheckResourceAttrSet("data.awsworkmail_domain.test", "ownership_verification_status"),
+				resource.TestCheckResourceAttrSet("data.awsworkmail_domain.test", "records.#"),
+			),
+		}},
+	})
+}
+
+func testAccDataSourceDomainsConfig(orgID string) string {
+	return `
+data "awsworkmail_domains" "test" {
+  organization_id = "` + orgID + `"
+}
+
+data "awsworkmail_domain" "test" {
+  organization_id = "` + orgID + `"
+  domain          = data.awsworkmail_domains.test.default_mail_domain
+}
+`
+}
@@ -0,0 +1,218 @@
+package awsworkmail
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/workmail"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                   = &organizationDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &organizationDataSource{}
+)
+
+// organizationDataSource is the data source implementation.
+type organizationDataSource struct {
+	cfg aws.Config
+}
+
+func NewOrganizationDataSource() datasource.DataSource {
+	return &organizationDataSource{}
+}
+
+type organizationDataSourceModel struct {
+	OrganizationID    types.String `tfsdk:"organization_id"`
+	Alias             types.String `tfsdk:"alias"`
+	ARN               types.String `tfsdk:"arn"`
+	State             types.String `tfsdk:"state"`
+	DefaultMailDomain types.String `tfsdk:"default_mail_domain"`
+	DirectoryID       types.String `tfsdk:"directory_id"`
+	DirectoryType     types.String `tfsdk:"directory_type"`
+	Domains           types.List   `tfsdk:"domains"`
+}
+
+// organizationDomainAttrTypes describes an element of the domains attribute.
+var organizationDomainAttrTypes = map[string]attr.Type{
+	"domain_name": types.StringType,
+	"default":     types.BoolType,
+}
+
+func (d *organizationDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organization"
+}
+
+func (d *organizationDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Data source for looking up an AWS WorkMail organization by alias or ID.",
+		Attributes: map[string]schema.Attribute{
+			"organization_id": schema.StringAttribute{
+				Description: "The WorkMail Organization ID. Exactly one of organization_id or alias must be set.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"alias": schema.StringAttribute{
+				Description: "The alias of the organization. Exactly one of organization_id or alias must be set.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"arn": schema.StringAttribute{
+				Description: "The ARN of the organization.",
+				Computed:    true,
+			},
+			"state": schema.StringAttribute{
+				Description: "The state of the organization.",
+				Computed:    true,
+			},
+			"default_mail_domain": schema.StringAttribute{
+				Description: "The default mail domain of the organization.",
+				Computed:    true,
+			},
+			"directory_id": schema.StringAttribute{
+				Description: "The identifier for the directory associated with the organization.",
+				Computed:    true,
+			},
+			"directory_type": schema.StringAttribute{
+				Description: "The type of directory associated with the organization.",
+				Computed:    true,
+			},
+			"domains": schema.ListNestedAttribute{
+				Description: "The mail domains registered with the organization.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"domain_name": schema.StringAttribute{
+							Description: "The domain name.",
+							Computed:    true,
+						},
+						"default": schema.BoolAttribute{
+							Description: "Whether this is the default mail domain of the organization.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *organizationDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	pd, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.cfg = pd.cfg
+}
+
+func (d *organizationDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var data organizationDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Defer validation until both values are known.
+	if data.OrganizationID.IsUnknown() || data.Alias.IsUnknown() {
+		return
+	}
+	if data.OrganizationID.IsNull() == data.Alias.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("organization_id"),
+			"Invalid organization lookup",
+			"Exactly one of organization_id or alias must be set.",
+		)
+	}
+}
+
+func (d *organizationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data organizationDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := workmail.NewFromConfig(d.cfg)
+
+	orgID := data.OrganizationID.ValueString()
+	if data.OrganizationID.IsNull() {
+		var err error
+		orgID, err = findOrganizationIDByAlias(ctx, client, data.Alias.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to find WorkMail organization", err.Error())
+			return
+		}
+	}
+
+	output, err := client.DescribeOrganization(ctx, &workmail.DescribeOrganizationInput{
+		OrganizationId: aws.String(orgID),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to describe WorkMail organization", err.Error())
+		return
+	}
+	data.OrganizationID = types.StringValue(orgID)
+	data.Alias = types.StringPointerValue(output.Alias)
+	data.ARN = types.StringPointerValue(output.ARN)
+	data.State = types.StringPointerValue(output.State)
+	data.DefaultMailDomain = types.StringPointerValue(output.DefaultMailDomain)
+	data.DirectoryID = types.StringPointerValue(output.DirectoryId)
+	data.DirectoryType = types.StringPointerValue(output.DirectoryType)
+
+	summaries, err := listMailDomains(ctx, client, orgID)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to list WorkMail mail domains", err.Error())
+		return
+	}
+	domains := []attr.Value{}
+	for _, domain := range summaries {
+		obj, diags := types.ObjectValue(organizationDomainAttrTypes, map[string]attr.Value{
+			"domain_name": types.StringPointerValue(domain.DomainName),
+			"default":     types.BoolValue(domain.DefaultDomain),
+		})
+		resp.Diagnostics.Append(diags...)
+		domains = append(domains, obj)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domainList, diags := types.ListValue(types.ObjectType{AttrTypes: organizationDomainAttrTypes}, domains)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Domains = domainList
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// findOrganizationIDByAlias returns the ID of the organization with the given
+// alias. Organizations in the Deleted state are ignored.
+func findOrganizationIDByAlias(ctx context.Context, client *workmail.Client, alias string) (string, error) {
+	orgs, err := listOrganizations(ctx, client)
+	if err != nil {
+		return "", err
+	}
+	for _, org := range orgs {
+		if aws.ToString(org.Alias) == alias && aws.ToString(org.State) != "Deleted" && org.OrganizationId != nil {
+			return *org.OrganizationId, nil
+		}
+	}
+	return "", fmt.Errorf("no WorkMail organization found with alias %q", alias)
+}
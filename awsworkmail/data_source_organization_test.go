@@ -0,0 +1,48 @@
+package awsworkmail
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDataSourceOrganization_basic(t *testing.T) {
+	orgAlias := os.Getenv("TF_AWSWORKMAIL_ORG_ALIAS")
+	if orgAlias == "" {
+		orgAlias = "tfacc-org-datasource"
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{{
+			Config: testAccDataSourceOrganizationConfig(orgAlias),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttrPair("data.awsworkmail_organization.by_alias", "organization_id", "awsworkmail_organization.test", "id"),
+				resource.TestCheckResourceAttrPair("data.awsworkmail_organization.by_id", "alias", "awsworkmail_organization.test", "alias"),
+				resource.TestCheckResourceAttrSet("data.awsworkmail_organization.by_id", "arn"),
+				resource.TestCheckResourceAttr("data.awsworkmail_organization.by_id", "state", "Active"),
+				resource.TestCheckResourceAttrSet("data.awsworkmail_organization.by_id", "default_mail_domain"),
+				resource.TestCheckResourceAttrSet("data.awsworkmail_organization.by_id", "domains.#"),
+			),
+		}},
+	})
+}
+
+func testAccDataSourceOrganizationConfig(alias string) string {
+	return fmt.Sprintf(`
+resource "awsworkmail_organization" "test" {
+  alias = "%s"
+}
+
+data "awsworkmail_organization" "by_alias" {
+  alias = awsworkmail_organization.test.alias
+}
+
+data "awsworkmail_organization" "by_id" {
+  organization_id = awsworkmail_organization.test.id
+}
+`, alias)
+}
@@ -0,0 +1,226 @@
+package awsworkmail
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/workmail"
+	wmtypes "github.com/aws/aws-sdk-go-v2/service/workmail/types"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                   = &organizationsDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &organizationsDataSource{}
+)
+
+// organizationsDataSource is the data source implementation.
+type organizationsDataSource struct {
+	cfg aws.Config
+}
+
+func NewOrganizationsDataSource() datasource.DataSource {
+	return &organizationsDataSource{}
+}
+
+type organizationsDataSourceModel struct {
+	State         types.String `tfsdk:"state"`
+	AliasPrefix   types.String `tfsdk:"alias_prefix"`
+	AliasRegex    types.String `tfsdk:"alias_regex"`
+	IDs           types.List   `tfsdk:"ids"`
+	Organizations types.List   `tfsdk:"organizations"`
+}
+
+// organizationSummaryAttrTypes describes an element of the organizations attribute.
+var organizationSummaryAttrTypes = map[string]attr.Type{
+	"organization_id":     types.StringType,
+	"alias":               types.StringType,
+	"state":               types.StringType,
+	"default_mail_domain": types.StringType,
+}
+
+func (d *organizationsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organizations"
+}
+
+func (d *organizationsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Data source for listing the AWS WorkMail organizations in the account and region.",
+		Attributes: map[string]schema.Attribute{
+			"state": schema.StringAttribute{
+				Description: "Only return organizations in this state (for example `Active`). Matching is case-insensitive.",
+				Optional:    true,
+			},
+			"alias_prefix": schema.StringAttribute{
+				Description: "Only return organizations whose alias starts with this prefix.",
+				Optional:    true,
+			},
+			"alias_regex": schema.StringAttribute{
+				Description: "Only return organizations whose alias matches this regular expression (Go RE2 syntax).",
+				Optional:    true,
+			},
+			"ids": schema.ListAttribute{
+				Description: "The IDs of the matching organizations.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"organizations": schema.ListNestedAttribute{
+				Description: "The matching organizations.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"organization_id": schema.StringAttribute{
+							Description: "The WorkMail Organization ID.",
+							Computed:    true,
+						},
+						"alias": schema.StringAttribute{
+							Description: "The alias of the organization.",
+							Computed:    true,
+						},
+						"state": schema.StringAttribute{
+							Description: "The state of the organization.",
+							Computed:    true,
+						},
+						"default_mail_domain": schema.StringAttribute{
+							Description: "The default mail domain of the organization.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *organizationsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	pd, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.cfg = pd.cfg
+}
+
+func (d *organizationsDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var aliasRegex types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("alias_regex"), &aliasRegex)...)
+	if resp.Diagnostics.HasError() || aliasRegex.IsNull() || aliasRegex.IsUnknown() {
+		return
+	}
+	if _, err := regexp.Compile(aliasRegex.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("alias_regex"), "Invalid alias_regex", err.Error())
+	}
+}
+
+func (d *organizationsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data organizationsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter := organizationFilter{
+		state:       data.State.ValueString(),
+		aliasPrefix: data.AliasPrefix.ValueString(),
+	}
+	if !data.AliasRegex.IsNull() {
+		re, err := regexp.Compile(data.AliasRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("alias_regex"), "Invalid alias_regex", err.Error())
+			return
+		}
+		filter.aliasRegex = re
+	}
+
+	client := workmail.NewFromConfig(d.cfg)
+
+	orgs, err := listOrganizations(ctx, client)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to list WorkMail organizations", err.Error())
+		return
+	}
+
+	ids := []string{}
+	values := []attr.Value{}
+	for _, org := range orgs {
+		if !filter.match(org) {
+			continue
+		}
+		obj, diags := types.ObjectValue(organizationSummaryAttrTypes, map[string]attr.Value{
+			"organization_id":     types.StringPointerValue(org.OrganizationId),
+			"alias":               types.StringPointerValue(org.Alias),
+			"state":               types.StringPointerValue(org.State),
+			"default_mail_domain": types.StringPointerValue(org.DefaultMailDomain),
+		})
+		resp.Diagnostics.Append(diags...)
+		ids = append(ids, aws.ToString(org.OrganizationId))
+		values = append(values, obj)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	idList, diags := types.ListValueFrom(ctx, types.StringType, ids)
+	resp.Diagnostics.Append(diags...)
+	orgList, diags := types.ListValue(types.ObjectType{AttrTypes: organizationSummaryAttrTypes}, values)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.IDs = idList
+	data.Organizations = orgList
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// organizationFilter selects organization summaries by state and alias.
+// Empty fields match everything.
+type organizationFilter struct {
+	state       string
+	aliasPrefix string
+	aliasRegex  *regexp.Regexp
+}
+
+func (f organizationFilter) match(org wmtypes.OrganizationSummary) bool {
+	alias := aws.ToString(org.Alias)
+	if f.state != "" && !strings.EqualFold(f.state, aws.ToString(org.State)) {
+		return false
+	}
+	if f.aliasPrefix != "" && !strings.HasPrefix(alias, f.aliasPrefix) {
+		return false
+	}
+	if f.aliasRegex != nil && !f.aliasRegex.MatchString(alias) {
+		return false
+	}
+	return true
+}
+
+// listOrganizations returns every WorkMail organization, following NextToken.
+func listOrganizations(ctx context.Context, client *workmail.Client) ([]wmtypes.OrganizationSummary, error) {
+	var orgs []wmtypes.OrganizationSummary
+	paginator := workmail.NewListOrganizationsPaginator(client, &workmail.ListOrganizationsInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		orgs = append(orgs, page.OrganizationSummaries...)
+	}
+	return orgs, nil
+}
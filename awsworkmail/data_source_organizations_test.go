@@ -0,0 +1,73 @@
+package awsworkmail
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	wmtypes "github.com/aws/aws-sdk-go-v2/service/workmail/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestOrganizationFilter(t *testing.T) {
+	org := wmtypes.OrganizationSummary{
+		OrganizationId: aws.String("m-123"),
+		Alias:          aws.String("team-mail-prod"),
+		State:          aws.String("Active"),
+	}
+
+	cases := []struct {
+		name   string
+		filter organizationFilter
+		want   bool
+	}{
+		{"empty", organizationFilter{}, true},
+		{"state case-insensitive", organizationFilter{state: "active"}, true},
+		{"state mismatch", organizationFilter{state: "Deleted"}, false},
+		{"prefix", organizationFilter{aliasPrefix: "team-"}, true},
+		{"prefix mismatch", organizationFilter{aliasPrefix: "ops-"}, false},
+		{"regex", organizationFilter{aliasRegex: regexp.MustCompile(`-prod$`)}, true},
+		{"regex mismatch", organizationFilter{aliasRegex: regexp.MustCompile(`-dev$`)}, false},
+		{"combined", organizationFilter{state: "Active", aliasPrefix: "team-", aliasRegex: regexp.MustCompile(`-dev$`)}, false},
+	}
+	for _, tc := range cases {
+		if got := tc.filter.match(org); got != tc.want {
+			t.Errorf("%s: match() = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestAccDataSourceOrganizations_basic(t *testing.T) {
+	orgAlias := os.Getenv("TF_AWSWORKMAIL_ORG_ALIAS")
+	if orgAlias == "" {
+		orgAlias = "tfacc-org-list"
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{{
+			Config: testAccDataSourceOrganizationsConfig(orgAlias),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr("data.awsworkmail_organizations.test", "organizations.#", "1"),
+				resource.TestCheckResourceAttrPair("data.awsworkmail_organizations.test", "ids.0", "awsworkmail_organization.test", "id"),
+				resource.TestCheckResourceAttr("data.awsworkmail_organizations.test", "organizations.0.state", "Active"),
+			),
+		}},
+	})
+}
+
+func testAccDataSourceOrganizationsConfig(alias string) string {
+	return fmt.Sprintf(`
+resource "awsworkmail_organization" "test" {
+  alias = "%s"
+}
+
+data "awsworkmail_organizations" "test" {
+  state       = "Active"
+  alias_regex = "^${awsworkmail_organization.test.alias}$"
+}
+`, alias)
+}
@@ -6,13 +6,18 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/workmail"
+	wmtypes "github.com/aws/aws-sdk-go-v2/service/workmail/types"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure implementation satisfies the expected interfaces.
-var _ datasource.DataSource = &userDataSource{}
+var (
+	_ datasource.DataSource                   = &userDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &userDataSource{}
+)
 
 // userDataSource is the data source implementation.
 type userDataSource struct {
@@ -23,34 +28,76 @@ func NewUserDataSource() datasource.DataSource {
 	return &userDataSource{}
 }
 
+type userDataSourceModel struct {
+	OrganizationId types.String `tfsdk:"organization_id"`
+	UserId         types.String `tfsdk:"user_id"`
+	Name           types.String `tfsdk:"name"`
+	Email          types.String `tfsdk:"email"`
+	State          types.String `tfsdk:"state"`
+
+	EnabledDate              types.String  `tfsdk:"enabled_date"`
+	DisabledDate             types.String  `tfsdk:"disabled_date"`
+	MailboxProvisionedDate   types.String  `tfsdk:"mailbox_provisioned_date"`
+	MailboxDeprovisionedDate types.String  `tfsdk:"mailbox_deprovisioned_date"`
+	MailboxQuotaMB           types.Int64   `tfsdk:"mailbox_quota_mb"`
+	MailboxSizeMB            types.Float64 `tfsdk:"mailbox_size_mb"`
+}
+
 func (d *userDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_user"
 }
 
 func (d *userDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Description: "Data source for querying AWS WorkMail users.",
+		Description: "Data source for querying AWS WorkMail users by ID, name or email address.",
 		Attributes: map[string]schema.Attribute{
 			"organization_id": schema.StringAttribute{
 				Description: "The WorkMail Organization ID.",
 				Required:    true,
 			},
 			"user_id": schema.StringAttribute{
-				Description: "The WorkMail User ID.",
-				Required:    true,
+				Description: "The WorkMail User ID. Exactly one of user_id, name or email must be set.",
+				Optional:    true,
+				Computed:    true,
 			},
 			"name": schema.StringAttribute{
-				Description: "The name of the user.",
+				Description: "The name of the user. Exactly one of user_id, name or email must be set.",
+				Optional:    true,
 				Computed:    true,
 			},
 			"email": schema.StringAttribute{
-				Description: "The primary email of the user.",
+				Description: "The primary email of the user. When used for the lookup, an alias of the user also matches. Exactly one of user_id, name or email must be set.",
+				Optional:    true,
 				Computed:    true,
 			},
 			"state": schema.StringAttribute{
 				Description: "The state of the user.",
 				Computed:    true,
 			},
+			"enabled_date": schema.StringAttribute{
+				Description: "When the user was last enabled, in RFC 3339 format.",
+				Computed:    true,
+			},
+			"disabled_date": schema.StringAttribute{
+				Description: "When the user was last disabled, in RFC 3339 format.",
+				Computed:    true,
+			},
+			"mailbox_provisioned_date": schema.StringAttribute{
+				Description: "When the mailbox of the user was provisioned, in RFC 3339 format.",
+				Computed:    true,
+			},
+			"mailbox_deprovisioned_date": schema.StringAttribute{
+				Description: "When the mailbox of the user was deprovisioned, in RFC 3339 format.",
+				Computed:    true,
+			},
+			"mailbox_quota_mb": schema.Int64Attribute{
+				Description: "The maximum size of the mailbox of the user, in MB. Null while the user has no mailbox.",
+				Computed:    true,
+			},
+			"mailbox_size_mb": schema.Float64Attribute{
+				Description: "The current size of the mailbox of the user, in MB. Null while the user has no mailbox.",
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -60,27 +107,47 @@ func (d *userDataSource) Configure(_ context.Context, req datasource.ConfigureRe
 		return
 	}
 
-	cfg, ok := req.ProviderData.(aws.Config)
+	pd, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected aws.Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	d.cfg = cfg
+	d.cfg = pd.cfg
 }
 
-func (d *userDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var data struct {
-		OrganizationId types.String `tfsdk:"organization_id"`
-		UserId         types.String `tfsdk:"user_id"`
-		Name           types.String `tfsdk:"name"`
-		Email          types.String `tfsdk:"email"`
-		State          types.String `tfsdk:"state"`
+func (d *userDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var data userDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
+	// Defer validation until all values are known.
+	if data.UserId.IsUnknown() || data.Name.IsUnknown() || data.Email.IsUnknown() {
+		return
+	}
+	set := 0
+	for _, v := range []types.String{data.UserId, data.Name, data.Email} {
+		if !v.IsNull() {
+			set++
+		}
+	}
+	if set != 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("user_id"),
+			"Invalid user lookup",
+			"Exactly one of user_id, name or email must be set.",
+		)
+	}
+}
+
+func (d *userDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data userDataSourceModel
+
 	diag := req.Config.Get(ctx, &data)
 	resp.Diagnostics.Append(diag...)
 	if resp.Diagnostics.HasError() {
@@ -89,9 +156,32 @@ func (d *userDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 
 	client := workmail.NewFromConfig(d.cfg)
 
+	userID := data.UserId.ValueString()
+	switch {
+	case !data.Email.IsNull():
+		id, err := findEntityIDByEmail(ctx, client, data.OrganizationId.ValueString(), data.Email.ValueString(), wmtypes.EntityTypeUser)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to find WorkMail user", err.Error())
+			return
+		}
+		userID = id
+	case !data.Name.IsNull():
+		id, err := findUserIDByName(ctx, client, data.OrganizationId.ValueString(), data.Name.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to find WorkMail user", err.Error())
+			return
+		}
+		if id == "" {
+			resp.Diagnostics.AddError("Unable to find WorkMail user", fmt.Sprintf("no WorkMail user found with name %q", data.Name.ValueString()))
+			return
+		}
+		userID = id
+	}
+	data.UserId = types.StringValue(userID)
+
 	input := &workmail.DescribeUserInput{
 		OrganizationId: aws.String(data.OrganizationId.ValueString()),
-		UserId:         aws.String(data.UserId.ValueString()),
+		UserId:         aws.String(userID),
 	}
 
 	output, err := client.DescribeUser(ctx, input)
@@ -111,6 +201,21 @@ func (d *userDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 	if output != nil && output.State != "" {
 		data.State = types.StringValue(string(output.State))
 	}
+	if output != nil {
+		data.EnabledDate = timeStringValue(output.EnabledDate)
+		data.DisabledDate = timeStringValue(output.DisabledDate)
+		data.MailboxProvisionedDate = timeStringValue(output.MailboxProvisionedDate)
+		data.MailboxDeprovisionedDate = timeStringValue(output.MailboxDeprovisionedDate)
+	}
+
+	data.MailboxQuotaMB, data.MailboxSizeMB, err = getMailboxDetails(ctx, client, data.OrganizationId.ValueString(), userID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to get WorkMail mailbox details",
+			err.Error(),
+		)
+		return
+	}
 
 	diag = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diag...)
@@ -36,3 +36,46 @@ data "awsworkmail_user" "test" {
 }
 `
 }
+
+func TestAccDataSourceUser_lookup(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{{
+			Config: testAccDataSourceUserLookupConfig(),
+			Check: resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttrPair("data.awsworkmail_user.by_name", "user_id", "awsworkmail_user.test", "id"),
+				resource.TestCheckResourceAttrPair("data.awsworkmail_user.by_email", "user_id", "awsworkmail_user.test", "id"),
+				resource.TestCheckResourceAttrPair("data.awsworkmail_user.by_email", "name", "awsworkmail_user.test", "name"),
+				resource.TestCheckResourceAttrSet("data.awsworkmail_user.by_name", "enabled_date"),
+				resource.TestCheckResourceAttrSet("data.awsworkmail_user.by_name", "mailbox_quota_mb"),
+				resource.TestCheckResourceAttrSet("awsworkmail_user.test", "mailbox_provisioned_date"),
+			),
+		}},
+	})
+}
+
+func testAccDataSourceUserLookupConfig() string {
+	return `
+resource "awsworkmail_organization" "test" {
+  alias = "tfacc-user-lookup"
+}
+
+resource "awsworkmail_user" "test" {
+  organization_id = awsworkmail_organization.test.id
+  name            = "tfacc.lookup"
+  display_name    = "Tfacc Lookup"
+  password        = "ChangeMe123!"
+}
+
+data "awsworkmail_user" "by_name" {
+  organization_id = awsworkmail_organization.test.id
+  name            = awsworkmail_user.test.name
+}
+
+data "awsworkmail_user" "by_email" {
+  organization_id = awsworkmail_organization.test.id
+  email           = awsworkmail_user.test.email
+}
+`
+}
@@ -0,0 +1,238 @@
+package awsworkmail
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/workmail"
+	wmtypes "github.com/aws/aws-sdk-go-v2/service/workmail/types"
+)
+
+// domainUsage is an address on a mail domain held by a WorkMail entity.
+type domainUsage struct {
+	EntityID   string
+	EntityName string
+	EntityType string
+	Address    string
+	Primary    bool
+}
+
+func (u domainUsage) String() string {
+	kind := "alias"
+	if u.Primary {
+		kind = "primary address"
+	}
+	return fmt.Sprintf("%s %s (%s): %s %s", u.EntityType, u.EntityName, u.EntityID, kind, u.Address)
+}
+
+// domainEntity is a user, group or resource that can hold email addresses.
+type domainEntity struct {
+	id         string
+	name       string
+	entityType string
+	email      string
+	state      wmtypes.EntityState
+}
+
+// addressOnDomain reports whether address belongs to domain.
+func addressOnDomain(address, domain string) bool {
+	return strings.HasSuffix(strings.ToLower(address), "@"+strings.ToLower(domain))
+}
+
+// listEntityAddresses returns the primary address of every enabled user,
+// group and resource of the organization and, when withAliases is set, their
+// aliases. Aliases cost one ListAliases call per entity.
+func listEntityAddresses(ctx context.Context, client *workmail.Client, organizationID string, withAliases bool) ([]domainUsage, error) {
+	entities, err := listMailEntities(ctx, client, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	var addresses []domainUsage
+	for _, entity := range entities {
+		if entity.state != wmtypes.EntityStateEnabled {
+			continue
+		}
+		if entity.email != "" {
+			addresses = append(addresses, domainUsage{
+				EntityID:   entity.id,
+				EntityName: entity.name,
+				EntityType: entity.entityType,
+				Address:    entity.email,
+				Primary:    true,
+			})
+		}
+		if !withAliases {
+			continue
+		}
+
+		aliases, err := listAliases(ctx, client, organizationID, entity.id)
+		if err != nil {
+			return nil, err
+		}
+		for _, alias := range aliases {
+			if strings.EqualFold(alias, entity.email) {
+				continue
+			}
+			addresses = append(addresses, domainUsage{
+				EntityID:   entity.id,
+				EntityName: entity.name,
+				EntityType: entity.entityType,
+				Address:    alias,
+			})
+		}
+	}
+	return addresses, nil
+}
+
+// usageOnDomain returns the addresses that belong to domain.
+func usageOnDomain(addresses []domainUsage, domain string) []domainUsage {
+	var usage []domainUsage
+	for _, a := range addresses {
+		if addressOnDomain(a.Address, domain) {
+			usage = append(usage, a)
+		}
+	}
+	return usage
+}
+
+// releaseConflicts returns a description of every primary address in usage
+// that releaseDomain cannot move to targetDomain, because the address it
+// would move to is already held by another entity.
+func releaseConflicts(addresses, usage []domainUsage, targetDomain string) []string {
+	held := map[string]domainUsage{}
+	for _, a := range addresses {
+		held[strings.ToLower(a.Address)] = a
+	}
+
+	var conflicts []string
+	for _, u := range usage {
+		if !u.Primary {
+			continue
+		}
+		target := releasedAddress(u.Address, targetDomain)
+		if owner, ok := held[strings.ToLower(target)]; ok && owner.EntityID != u.EntityID {
+			conflicts = append(conflicts, fmt.Sprintf("%s cannot move to %s, already held by %s", u, target, owner))
+		}
+	}
+	return conflicts
+}
+
+// releasedAddress returns address with its domain replaced by targetDomain.
+func releasedAddress(address, targetDomain string) string {
+	return address[:strings.LastIndex(address, "@")] + "@" + targetDomain
+}
+
+// releaseTargetDomain returns the domain that releaseDomain moves primary
+// addresses to: the default domain, or the awsapps.com test domain when the
+// domain being released is itself the default. It returns an empty string if
+// there is no such domain.
+func releaseTargetDomain(ctx context.Context, client *workmail.Client, organizationID string, isDefault bool) (string, error) {
+	if isDefault {
+		return findTestMailDomain(ctx, client, organizationID)
+	}
+	out, err := client.DescribeOrganization(ctx, &workmail.DescribeOrganizationInput{
+		OrganizationId: aws.String(organizationID),
+	})
+	if err != nil {
+		return "", err
+	}
+	return aws.ToString(out.DefaultMailDomain), nil
+}
+
+// listMailEntities returns the users, groups and resources of the organization.
+func listMailEntities(ctx context.Context, client *workmail.Client, organizationID string) ([]domainEntity, error) {
+	var entities []domainEntity
+
+	users := workmail.NewListUsersPaginator(client, &workmail.ListUsersInput{
+		OrganizationId: aws.String(organizationID),
+	})
+	for users.HasMorePages() {
+		page, err := users.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, u := range page.Users {
+			entities = append(entities, domainEntity{aws.ToString(u.Id), aws.ToString(u.Name), "user", aws.ToString(u.Email), u.State})
+		}
+	}
+
+	groups := workmail.NewListGroupsPaginator(client, &workmail.ListGroupsInput{
+		OrganizationId: aws.String(organizationID),
+	})
+	for groups.HasMorePages() {
+		page, err := groups.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, g := range page.Groups {
+			entities = append(entities, domainEntity{aws.ToString(g.Id), aws.ToString(g.Name), "group", aws.ToString(g.Email), g.State})
+		}
+	}
+
+	resources := workmail.NewListResourcesPaginator(client, &workmail.ListResourcesInput{
+		OrganizationId: aws.String(organizationID),
+	})
+	for resources.HasMorePages() {
+		page, err := resources.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range page.Resources {
+			entities = append(entities, domainEntity{aws.ToString(r.Id), aws.ToString(r.Name), "resource", aws.ToString(r.Email), r.State})
+		}
+	}
+
+	return entities, nil
+}
+
+// listAliases returns every alias of the entity, following NextToken.
+func listAliases(ctx context.Context, client *workmail.Client, organizationID, entityID string) ([]string, error) {
+	var aliases []string
+	paginator := workmail.NewListAliasesPaginator(client, &workmail.ListAliasesInput{
+		OrganizationId: aws.String(organizationID),
+		EntityId:       aws.String(entityID),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		aliases = append(aliases, page.Aliases...)
+	}
+	return aliases, nil
+}
+
+// releaseDomain moves every primary address on domain to the same local part
+// on defaultDomain and deletes every alias on domain, so that the domain can
+// be deregistered. Callers check releaseConflicts first.
+func releaseDomain(ctx context.Context, client *workmail.Client, organizationID, defaultDomain string, usage []domainUsage) error {
+	for _, u := range usage {
+		if u.Primary {
+			if err := updatePrimaryEmail(ctx, client, organizationID, u.EntityID, u.Address, releasedAddress(u.Address, defaultDomain), false); err != nil {
+				return fmt.Errorf("moving %s to %s: %w", u, defaultDomain, err)
+			}
+			continue
+		}
+		_, err := client.DeleteAlias(ctx, &workmail.DeleteAliasInput{
+			OrganizationId: aws.String(organizationID),
+			EntityId:       aws.String(u.EntityID),
+			Alias:          aws.String(u.Address),
+		})
+		if err != nil && !strings.Contains(err.Error(), "EntityNotFoundException") {
+			return fmt.Errorf("removing %s: %w", u, err)
+		}
+	}
+	return nil
+}
+
+// formatDomainUsage renders usage as an indented list for diagnostics.
+func formatDomainUsage(usage []domainUsage) string {
+	lines := make([]string, 0, len(usage))
+	for _, u := range usage {
+		lines = append(lines, "  - "+u.String())
+	}
+	return strings.Join(lines, "\n")
+}
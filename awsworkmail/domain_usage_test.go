@@ -0,0 +1,71 @@
+package awsworkmail
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAddressOnDomain(t *testing.T) {
+	cases := []struct {
+		address, domain string
+		want            bool
+	}{
+		{"jane@example.com", "example.com", true},
+		{"Jane@Example.COM", "example.com", true},
+		{"jane@sub.example.com", "example.com", false},
+		{"jane@notexample.com", "example.com", false},
+		{"", "example.com", false},
+	}
+	for _, tc := range cases {
+		if got := addressOnDomain(tc.address, tc.domain); got != tc.want {
+			t.Errorf("addressOnDomain(%q, %q) = %v, want %v", tc.address, tc.domain, got, tc.want)
+		}
+	}
+}
+
+func TestDomainInUseDetail(t *testing.T) {
+	usage := []domainUsage{
+		{EntityID: "u-1", EntityName: "jane", EntityType: "user", Address: "jane@example.com", Primary: true},
+		{EntityID: "g-1", EntityName: "sales", EntityType: "group", Address: "info@example.com"},
+	}
+
+	got := domainInUseDetail("example.com", true, usage)
+	for _, want := range []string{
+		"is the default mail domain",
+		"used by 2 address(es)",
+		"user jane (u-1): primary address jane@example.com",
+		"group sales (g-1): alias info@example.com",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("domainInUseDetail() = %q, missing %q", got, want)
+		}
+	}
+}
+
+func TestReleaseConflicts(t *testing.T) {
+	addresses := []domainUsage{
+		{EntityID: "u-1", EntityName: "jane", EntityType: "user", Address: "jane@old.com", Primary: true},
+		{EntityID: "u-2", EntityName: "john", EntityType: "user", Address: "john@old.com", Primary: true},
+		{EntityID: "u-2", EntityName: "john", EntityType: "user", Address: "john@new.com"},
+		{EntityID: "g-1", EntityName: "sales", EntityType: "group", Address: "Jane@New.com", Primary: true},
+		{EntityID: "g-1", EntityName: "sales", EntityType: "group", Address: "info@old.com"},
+	}
+	usage := usageOnDomain(addresses, "old.com")
+	if len(usage) != 3 {
+		t.Fatalf("usageOnDomain() returned %d addresses, want 3", len(usage))
+	}
+
+	conflicts := releaseConflicts(addresses, usage, "new.com")
+	if len(conflicts) != 1 {
+		t.Fatalf("releaseConflicts() = %v, want one conflict", conflicts)
+	}
+	for _, want := range []string{"jane@old.com", "jane@new.com", "group sales (g-1)"} {
+		if !strings.Contains(conflicts[0], want) {
+			t.Errorf("conflict %q is missing %q", conflicts[0], want)
+		}
+	}
+
+	if conflicts := releaseConflicts(addresses, usage, "other.com"); len(conflicts) != 0 {
+		t.Errorf("releaseConflicts() = %v, want none", conflicts)
+	}
+}
@@ -0,0 +1,47 @@
+package awsworkmail
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/workmail"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// updatePrimaryEmail changes the primary address of an enabled user, group or
+// resource. WorkMail keeps the previous address as an alias; it is deleted
+// unless keepPrevious is set.
+func updatePrimaryEmail(ctx context.Context, client *workmail.Client, organizationID, entityID, previous, email string, keepPrevious bool) error {
+	_, err := client.UpdatePrimaryEmailAddress(ctx, &workmail.UpdatePrimaryEmailAddressInput{
+		OrganizationId: aws.String(organizationID),
+		EntityId:       aws.String(entityID),
+		Email:          aws.String(email),
+	})
+	if err != nil {
+		return err
+	}
+	if keepPrevious || previous == "" || strings.EqualFold(previous, email) {
+		return nil
+	}
+
+	_, err = client.DeleteAlias(ctx, &workmail.DeleteAliasInput{
+		OrganizationId: aws.String(organizationID),
+		EntityId:       aws.String(entityID),
+		Alias:          aws.String(previous),
+	})
+	if err != nil && !strings.Contains(err.Error(), "EntityNotFoundException") {
+		return err
+	}
+	return nil
+}
+
+// primaryEmailChanged reports whether the primary address of an entity that
+// is enabled, and stays enabled, must be changed with UpdatePrimaryEmailAddress
+// rather than by registering it again.
+func primaryEmailChanged(plan, state types.String, wasEnabled, enabled bool) bool {
+	if !wasEnabled || !enabled || plan.IsNull() || plan.IsUnknown() || plan.ValueString() == "" {
+		return false
+	}
+	return !strings.EqualFold(plan.ValueString(), state.ValueString())
+}
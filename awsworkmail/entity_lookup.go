@@ -0,0 +1,97 @@
+package awsworkmail
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/workmail"
+	wmtypes "github.com/aws/aws-sdk-go-v2/service/workmail/types"
+)
+
+// findEntityIDByEmail returns the ID of the entity whose primary address or
+// alias is email, and checks that it is of the expected type.
+func findEntityIDByEmail(ctx context.Context, client *workmail.Client, organizationID, email string, entityType wmtypes.EntityType) (string, error) {
+	out, err := client.DescribeEntity(ctx, &workmail.DescribeEntityInput{
+		OrganizationId: aws.String(organizationID),
+		Email:          aws.String(email),
+	})
+	if err != nil {
+		if isEntityNotFound(err) {
+			return "", fmt.Errorf("no WorkMail entity found with email %q", email)
+		}
+		return "", err
+	}
+	if out.Type != entityType {
+		return "", fmt.Errorf("email %q belongs to a %s, not a %s", email, out.Type, entityType)
+	}
+	return aws.ToString(out.EntityId), nil
+}
+
+// findUserIDByName returns the ID of the user with the given name, or an empty
+// string if there is none. Deleted users are ignored.
+func findUserIDByName(ctx context.Context, client *workmail.Client, organizationID, name string) (string, error) {
+	paginator := workmail.NewListUsersPaginator(client, &workmail.ListUsersInput{
+		OrganizationId: aws.String(organizationID),
+		Filters:        &wmtypes.ListUsersFilters{UsernamePrefix: aws.String(name)},
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return "", err
+		}
+		for _, u := range page.Users {
+			if strings.EqualFold(aws.ToString(u.Name), name) && u.State != wmtypes.EntityStateDeleted {
+				return aws.ToString(u.Id), nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// findGroupIDByName returns the ID of the group with the given name, or an
+// empty string if there is none. Deleted groups are ignored.
+func findGroupIDByName(ctx context.Context, client *workmail.Client, organizationID, name string) (string, error) {
+	paginator := workmail.NewListGroupsPaginator(client, &workmail.ListGroupsInput{
+		OrganizationId: aws.String(organizationID),
+		Filters:        &wmtypes.ListGroupsFilters{NamePrefix: aws.String(name)},
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return "", err
+		}
+		for _, g := range page.Groups {
+			if strings.EqualFold(aws.ToString(g.Name), name) && g.State != wmtypes.EntityStateDeleted {
+				return aws.ToString(g.Id), nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// resolveImportEntityID turns the second part of an import ID, which may be an
+// entity ID, a name or an email address, into an entity ID. A reference that
+// matches no name is assumed to be an ID.
+func resolveImportEntityID(ctx context.Context, client *workmail.Client, organizationID, ref string, entityType wmtypes.EntityType) (string, error) {
+	if strings.Contains(ref, "@") {
+		return findEntityIDByEmail(ctx, client, organizationID, ref, entityType)
+	}
+
+	var id string
+	var err error
+	switch entityType {
+	case wmtypes.EntityTypeGroup:
+		id, err = findGroupIDByName(ctx, client, organizationID, ref)
+	default:
+		id, err = findUserIDByName(ctx, client, organizationID, ref)
+	}
+	if err != nil {
+		return "", err
+	}
+	if id == "" {
+		return ref, nil
+	}
+	return id, nil
+}
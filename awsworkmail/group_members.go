@@ -0,0 +1,482 @@
+package awsworkmail
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/workmail"
+	wmtypes "github.com/aws/aws-sdk-go-v2/service/workmail/types"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// memberTypeResource identifies resources, which ListGroupMembers does not
+// model as a separate member type.
+const memberTypeResource wmtypes.MemberType = "RESOURCE"
+
+// memberTypes are the entity types that can be group members.
+var memberTypes = []wmtypes.MemberType{wmtypes.MemberTypeUser, wmtypes.MemberTypeGroup, memberTypeResource}
+
+const (
+	// defaultMemberTimeout bounds a whole membership change, readiness checks
+	// included, when no create or update timeout is configured.
+	defaultMemberTimeout = 20 * time.Minute
+	// memberEnabledTimeout caps the wait for new members to be enabled.
+	memberEnabledTimeout = 5 * time.Minute
+	// memberEnabledPollInterval is the delay between member state polls.
+	memberEnabledPollInterval = 10 * time.Second
+	// memberDescribeLimit is the number of pending members of one type that
+	// are described one by one; above it, the whole type is listed instead.
+	memberDescribeLimit = 20
+	// memberWorkers is the number of membership calls made concurrently.
+	memberWorkers = 8
+)
+
+// listGroupMembers returns every member of the group, following NextToken.
+// Deleted members are skipped.
+func listGroupMembers(ctx context.Context, client *workmail.Client, organizationID, groupID string) ([]wmtypes.Member, error) {
+	var members []wmtypes.Member
+	paginator := workmail.NewListGroupMembersPaginator(client, &workmail.ListGroupMembersInput{
+		OrganizationId: aws.String(organizationID),
+		GroupId:        aws.String(groupID),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range page.Members {
+			if m.Id == nil || m.State == wmtypes.EntityStateDeleted {
+				continue
+			}
+			members = append(members, m)
+		}
+	}
+	return members, nil
+}
+
+// memberIDs returns the sorted, de-duplicated IDs of members.
+func memberIDs(members []wmtypes.Member) []string {
+	unique := map[string]struct{}{}
+	for _, m := range members {
+		unique[aws.ToString(m.Id)] = struct{}{}
+	}
+	ids := make([]string, 0, len(unique))
+	for id := range unique {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// upgradeGroupMembers converts member IDs from version 0 state into a set,
+// dropping empty IDs.
+func upgradeGroupMembers(ctx context.Context, ids []string) (types.Set, diag.Diagnostics) {
+	kept := []string{}
+	for _, id := range ids {
+		if id != "" {
+			kept = append(kept, id)
+		}
+	}
+	sort.Strings(kept)
+	return types.SetValueFrom(ctx, types.StringType, kept)
+}
+
+// diffMembers returns the sorted member IDs to add to and remove from a group
+// to go from current to desired.
+func diffMembers(current, desired []string) (add, remove []string) {
+	have := map[string]bool{}
+	for _, id := range current {
+		have[id] = true
+	}
+	want := map[string]bool{}
+	for _, id := range desired {
+		want[id] = true
+	}
+	add, remove = []string{}, []string{}
+	for id := range want {
+		if !have[id] {
+			add = append(add, id)
+		}
+	}
+	for id := range have {
+		if !want[id] {
+			remove = append(remove, id)
+		}
+	}
+	sort.Strings(add)
+	sort.Strings(remove)
+	return add, remove
+}
+
+// memberState is the type and state of a prospective group member.
+type memberState struct {
+	memberType wmtypes.MemberType
+	state      wmtypes.EntityState
+}
+
+// listEntityStates returns the type and state of every entity of the given
+// types in the organization, keyed by entity ID.
+func listEntityStates(ctx context.Context, client *workmail.Client, organizationID string, entityTypes []wmtypes.MemberType) (map[string]memberState, error) {
+	states := map[string]memberState{}
+	for _, entityType := range entityTypes {
+		switch entityType {
+		case wmtypes.MemberTypeUser:
+			paginator := workmail.NewListUsersPaginator(client, &workmail.ListUsersInput{
+				OrganizationId: aws.String(organizationID),
+			})
+			for paginator.HasMorePages() {
+				page, err := paginator.NextPage(ctx)
+				if err != nil {
+					return nil, err
+				}
+				for _, u := range page.Users {
+					states[aws.ToString(u.Id)] = memberState{entityType, u.State}
+				}
+			}
+		case wmtypes.MemberTypeGroup:
+			paginator := workmail.NewListGroupsPaginator(client, &workmail.ListGroupsInput{
+				OrganizationId: aws.String(organizationID),
+			})
+			for paginator.HasMorePages() {
+				page, err := paginator.NextPage(ctx)
+				if err != nil {
+					return nil, err
+				}
+				for _, g := range page.Groups {
+					states[aws.ToString(g.Id)] = memberState{entityType, g.State}
+				}
+			}
+		case memberTypeResource:
+			paginator := workmail.NewListResourcesPaginator(client, &workmail.ListResourcesInput{
+				OrganizationId: aws.String(organizationID),
+			})
+			for paginator.HasMorePages() {
+				page, err := paginator.NextPage(ctx)
+				if err != nil {
+					return nil, err
+				}
+				for _, r := range page.Resources {
+					states[aws.ToString(r.Id)] = memberState{entityType, r.State}
+				}
+			}
+		}
+	}
+	return states, nil
+}
+
+// describeMemberState returns the state of a member of a known type with the
+// matching Describe call, or an empty state if the entity does not exist.
+func describeMemberState(ctx context.Context, client *workmail.Client, organizationID, memberID string, memberType wmtypes.MemberType) (wmtypes.EntityState, error) {
+	var state wmtypes.EntityState
+	var err error
+	switch memberType {
+	case wmtypes.MemberTypeUser:
+		var out *workmail.DescribeUserOutput
+		out, err = client.DescribeUser(ctx, &workmail.DescribeUserInput{
+			OrganizationId: aws.String(organizationID),
+			UserId:         aws.String(memberID),
+		})
+		if err == nil {
+			state = out.State
+		}
+	case wmtypes.MemberTypeGroup:
+		var out *workmail.DescribeGroupOutput
+		out, err = client.DescribeGroup(ctx, &workmail.DescribeGroupInput{
+			OrganizationId: aws.String(organizationID),
+			GroupId:        aws.String(memberID),
+		})
+		if err == nil {
+			state = out.State
+		}
+	default:
+		var out *workmail.DescribeResourceOutput
+		out, err = client.DescribeResource(ctx, &workmail.DescribeResourceInput{
+			OrganizationId: aws.String(organizationID),
+			ResourceId:     aws.String(memberID),
+		})
+		if err == nil {
+			state = out.State
+		}
+	}
+	if isEntityNotFound(err) {
+		return "", nil
+	}
+	return state, err
+}
+
+// pollMemberStates reads the state of the pending members. Members whose type
+// was seen before are described one by one, or their whole type is listed
+// when many of them are pending. Only members never seen require listing
+// every type.
+func pollMemberStates(ctx context.Context, client *workmail.Client, organizationID string, pending []string, seen map[string]wmtypes.MemberType) (map[string]memberState, error) {
+	byType := map[wmtypes.MemberType][]string{}
+	unseen := false
+	for _, id := range pending {
+		if memberType, ok := seen[id]; ok {
+			byType[memberType] = append(byType[memberType], id)
+		} else {
+			unseen = true
+		}
+	}
+
+	var listed []wmtypes.MemberType
+	for _, memberType := range memberTypes {
+		if unseen || len(byType[memberType]) > memberDescribeLimit {
+			listed = append(listed, memberType)
+		}
+	}
+	states, err := listEntityStates(ctx, client, organizationID, listed)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, memberType := range memberTypes {
+		if slices.Contains(listed, memberType) {
+			continue
+		}
+		for _, id := range byType[memberType] {
+			state, err := describeMemberState(ctx, client, organizationID, id, memberType)
+			if err != nil {
+				return nil, err
+			}
+			if state != "" {
+				states[id] = memberState{memberType, state}
+			}
+		}
+	}
+	return states, nil
+}
+
+// waitForMembersEnabled polls the members until every one is ENABLED or ctx
+// is done. Members not found yet are polled too, since entities created in
+// the same run can take a moment to show up. It returns the members that were
+// never found and those that were found but not enabled when ctx was done.
+func waitForMembersEnabled(ctx context.Context, client *workmail.Client, organizationID string, ids []string) (missing, notEnabled []string, err error) {
+	seen := map[string]wmtypes.MemberType{}
+	pending := ids
+poll:
+	for len(pending) > 0 {
+		states, err := pollMemberStates(ctx, client, organizationID, pending, seen)
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			return nil, nil, err
+		}
+
+		var waiting []string
+		for _, id := range pending {
+			s, ok := states[id]
+			if ok && s.state != wmtypes.EntityStateDeleted {
+				seen[id] = s.memberType
+			}
+			if !ok || s.state != wmtypes.EntityStateEnabled {
+				waiting = append(waiting, id)
+			}
+		}
+		pending = waiting
+		if len(pending) == 0 {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			break poll
+		case <-time.After(memberEnabledPollInterval):
+		}
+	}
+
+	for _, id := range pending {
+		if _, ok := seen[id]; ok {
+			notEnabled = append(notEnabled, id)
+		} else {
+			missing = append(missing, id)
+		}
+	}
+	return missing, notEnabled, nil
+}
+
+// memberEnabledBudget returns how long to wait for new members to be enabled:
+// half of the time left before the deadline of ctx, at most
+// memberEnabledTimeout. The rest is left to the membership calls, so members
+// that never become enabled do not use up the time needed to add the others.
+func memberEnabledBudget(ctx context.Context) time.Duration {
+	budget := memberEnabledTimeout
+	if deadline, ok := ctx.Deadline(); ok {
+		if half := time.Until(deadline) / 2; half < budget {
+			budget = half
+		}
+	}
+	return budget
+}
+
+// memberChange adds a member to a group, or removes it.
+type memberChange struct {
+	memberID string
+	remove   bool
+}
+
+// runMemberChanges applies changes concurrently, holding a slot of sem for
+// each call, and returns the error of each change. Changes that would start
+// after ctx is done are not attempted.
+func runMemberChanges(ctx context.Context, client *workmail.Client, organizationID, groupID string, changes []memberChange, sem chan struct{}) []error {
+	errs := make([]error, len(changes))
+	var wg sync.WaitGroup
+	for i, c := range changes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			if err := ctx.Err(); err != nil {
+				errs[i] = fmt.Errorf("not attempted: %w", err)
+				return
+			}
+			if c.remove {
+				_, errs[i] = client.DisassociateMemberFromGroup(ctx, &workmail.DisassociateMemberFromGroupInput{
+					OrganizationId: aws.String(organizationID),
+					GroupId:        aws.String(groupID),
+					MemberId:       aws.String(c.memberID),
+				})
+				return
+			}
+			_, errs[i] = client.AssociateMemberToGroup(ctx, &workmail.AssociateMemberToGroupInput{
+				OrganizationId: aws.String(organizationID),
+				GroupId:        aws.String(groupID),
+				MemberId:       aws.String(c.memberID),
+			})
+		}()
+	}
+	wg.Wait()
+	return errs
+}
+
+// reconcileGroupMembers adds and removes group members, at most memberWorkers
+// calls at a time. Removals start right away; members to add are first
+// waited on until they are enabled, within memberEnabledBudget. Every failure
+// is reported, so one bad member does not hide the others.
+func reconcileGroupMembers(ctx context.Context, client *workmail.Client, organizationID, groupID string, add, remove []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	sem := make(chan struct{}, memberWorkers)
+
+	removals := make([]memberChange, 0, len(remove))
+	for _, id := range remove {
+		removals = append(removals, memberChange{memberID: id, remove: true})
+	}
+	var removeErrs []error
+	removed := make(chan struct{})
+	go func() {
+		defer close(removed)
+		removeErrs = runMemberChanges(ctx, client, organizationID, groupID, removals, sem)
+	}()
+
+	var additions []memberChange
+	if len(add) > 0 {
+		waitCtx, cancel := context.WithTimeout(ctx, memberEnabledBudget(ctx))
+		missing, notEnabled, err := waitForMembersEnabled(waitCtx, client, organizationID, add)
+		cancel()
+		if err != nil {
+			diags.AddWarning("Unable to check group member state", "Members are added without waiting for them to be enabled: "+err.Error())
+		}
+		for _, id := range missing {
+			diags.AddError("Unknown group member", fmt.Sprintf("No user, group or resource with ID %s exists in organization %s.", id, organizationID))
+		}
+		if len(notEnabled) > 0 {
+			diags.AddWarning("Members not enabled", "The following members were not enabled in time and may fail to be added: "+strings.Join(notEnabled, ", "))
+		}
+		for _, id := range add {
+			if !slices.Contains(missing, id) {
+				additions = append(additions, memberChange{memberID: id})
+			}
+		}
+	}
+	addErrs := runMemberChanges(ctx, client, organizationID, groupID, additions, sem)
+	<-removed
+
+	for i, err := range removeErrs {
+		if err != nil {
+			diags.AddError("Error removing member from group", fmt.Sprintf("Member %s: %s", removals[i].memberID, err))
+		}
+	}
+	for i, err := range addErrs {
+		if err != nil {
+			diags.AddError("Error adding member to group", fmt.Sprintf("Member %s: %s", additions[i].memberID, err))
+		}
+	}
+	return diags
+}
+
+// findMembershipCycle reports whether adding members to the group would
+// create a cycle, by following the nested groups of each member through
+// ListGroupMembers. It returns the path of group IDs that leads back to the
+// group, or nil.
+func findMembershipCycle(ctx context.Context, client *workmail.Client, organizationID, groupID string, members []string) ([]string, error) {
+	for _, member := range members {
+		if member == groupID {
+			return []string{groupID, groupID}, nil
+		}
+	}
+
+	// Only groups have members; list them once instead of probing every ID.
+	groups := map[string]bool{}
+	paginator := workmail.NewListGroupsPaginator(client, &workmail.ListGroupsInput{
+		OrganizationId: aws.String(organizationID),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, g := range page.Groups {
+			if g.State != wmtypes.EntityStateDeleted {
+				groups[aws.ToString(g.Id)] = true
+			}
+		}
+	}
+
+	visited := map[string]bool{}
+	var walk func(id string, path []string) ([]string, error)
+	walk = func(id string, path []string) ([]string, error) {
+		path = append(path, id)
+		if id == groupID {
+			return path, nil
+		}
+		if visited[id] {
+			return nil, nil
+		}
+		visited[id] = true
+
+		nested, err := listGroupMembers(ctx, client, organizationID, id)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range nested {
+			if m.Type != wmtypes.MemberTypeGroup {
+				continue
+			}
+			if cycle, err := walk(aws.ToString(m.Id), path); cycle != nil || err != nil {
+				return cycle, err
+			}
+		}
+		return nil, nil
+	}
+
+	for _, member := range members {
+		if !groups[member] {
+			continue
+		}
+		cycle, err := walk(member, []string{groupID})
+		if cycle != nil || err != nil {
+			return cycle, err
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,366 @@
+package awsworkmail
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/workmail"
+	wmtypes "github.com/aws/aws-sdk-go-v2/service/workmail/types"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestMemberIDs(t *testing.T) {
+	members := []wmtypes.Member{
+		{Id: aws.String("b")},
+		{Id: aws.String("a")},
+		{Id: aws.String("b")},
+	}
+	if got := memberIDs(members); !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Errorf("memberIDs() = %v", got)
+	}
+}
+
+func TestUpgradeGroupMembers(t *testing.T) {
+	ctx := context.Background()
+	set, diags := upgradeGroupMembers(ctx, []string{"u-2", "", "u-1"})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	var ids []string
+	set.ElementsAs(ctx, &ids, false)
+	if !reflect.DeepEqual(ids, []string{"u-1", "u-2"}) {
+		t.Errorf("upgraded members = %v", ids)
+	}
+}
+
+func TestDiffMembers(t *testing.T) {
+	add, remove := diffMembers([]string{"u-1", "u-2", "g-1"}, []string{"u-3", "u-2", "u-3", "g-2"})
+	if !reflect.DeepEqual(add, []string{"g-2", "u-3"}) {
+		t.Errorf("add = %v", add)
+	}
+	if !reflect.DeepEqual(remove, []string{"g-1", "u-1"}) {
+		t.Errorf("remove = %v", remove)
+	}
+
+	add, remove = diffMembers([]string{"u-1"}, []string{"u-1"})
+	if len(add) != 0 || len(remove) != 0 {
+		t.Errorf("expected no changes, got add=%v remove=%v", add, remove)
+	}
+}
+
+// stubWorkMail is an in-memory WorkMail organization with one group, served
+// over HTTP to a real client.
+type stubWorkMail struct {
+	mu          sync.Mutex
+	users       map[string]wmtypes.EntityState
+	groups      map[string]wmtypes.EntityState
+	emails      map[string]string
+	members     map[string]bool
+	nested      map[string][]string
+	failures    map[string]string
+	stuck       map[string]bool
+	delay       time.Duration
+	calls       map[string]int
+	inFlight    int
+	maxInFlight int
+}
+
+// stubRequest holds the request fields the stub looks at.
+type stubRequest struct {
+	MemberId string
+	UserId   string
+	GroupId  string
+	EntityId string
+	Email    string
+}
+
+func newStubWorkMail(users map[string]wmtypes.EntityState, members ...string) *stubWorkMail {
+	s := &stubWorkMail{
+		users:    users,
+		groups:   map[string]wmtypes.EntityState{},
+		emails:   map[string]string{},
+		members:  map[string]bool{},
+		nested:   map[string][]string{},
+		failures: map[string]string{},
+		stuck:    map[string]bool{},
+		calls:    map[string]int{},
+	}
+	for _, id := range members {
+		s.members[id] = true
+	}
+	return s
+}
+
+// config returns an AWS configuration that sends every request to the stub.
+func (s *stubWorkMail) config(t *testing.T) aws.Config {
+	srv := httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	t.Cleanup(srv.Close)
+	return aws.Config{
+		Region:       "us-east-1",
+		BaseEndpoint: aws.String(srv.URL),
+		Credentials:  aws.AnonymousCredentials{},
+		Retryer:      func() aws.Retryer { return aws.NopRetryer{} },
+	}
+}
+
+// client returns a WorkMail client that sends every request to the stub.
+func (s *stubWorkMail) client(t *testing.T) *workmail.Client {
+	return workmail.NewFromConfig(s.config(t))
+}
+
+func (s *stubWorkMail) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	op := strings.TrimPrefix(r.Header.Get("X-Amz-Target"), "WorkMailService.")
+	var input stubRequest
+	_ = json.NewDecoder(r.Body).Decode(&input)
+
+	out, errType := s.handle(op, input)
+	w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+	if errType != "" {
+		w.Header().Set("X-Amzn-ErrorType", errType)
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]string{"__type": errType, "Message": "stub " + errType})
+		return
+	}
+	_ = json.NewEncoder(w).Encode(out)
+}
+
+func (s *stubWorkMail) handle(op string, in stubRequest) (any, string) {
+	s.mu.Lock()
+	s.calls[op]++
+	if op == "AssociateMemberToGroup" || op == "DisassociateMemberFromGroup" {
+		s.inFlight++
+		s.maxInFlight = max(s.maxInFlight, s.inFlight)
+		s.mu.Unlock()
+		time.Sleep(s.delay)
+		s.mu.Lock()
+		s.inFlight--
+	}
+	defer s.mu.Unlock()
+
+	switch op {
+	case "ListUsers":
+		users := []map[string]string{}
+		for id, state := range s.users {
+			users = append(users, map[string]string{"Id": id, "State": string(state)})
+		}
+		return map[string]any{"Users": users}, ""
+	case "ListGroups":
+		groups := []map[string]string{}
+		for id, state := range s.groups {
+			groups = append(groups, map[string]string{"Id": id, "State": string(state)})
+		}
+		return map[string]any{"Groups": groups}, ""
+	case "ListResources":
+		return map[string]any{"Resources": []any{}}, ""
+	case "DescribeUser":
+		state, ok := s.users[in.UserId]
+		if !ok {
+			return nil, "EntityNotFoundException"
+		}
+		return map[string]string{"UserId": in.UserId, "State": string(state), "Email": s.emails[in.UserId]}, ""
+	case "DescribeGroup":
+		state, ok := s.groups[in.GroupId]
+		if !ok {
+			return nil, "EntityNotFoundException"
+		}
+		return map[string]string{"GroupId": in.GroupId, "State": string(state), "Email": s.emails[in.GroupId]}, ""
+	case "RegisterToWorkMail", "DeregisterFromWorkMail":
+		if errType := s.failures[in.EntityId]; errType != "" {
+			return nil, errType
+		}
+		if s.stuck[in.EntityId] {
+			return map[string]any{}, ""
+		}
+		if op == "RegisterToWorkMail" {
+			s.users[in.EntityId] = wmtypes.EntityStateEnabled
+			s.emails[in.EntityId] = in.Email
+		} else {
+			s.users[in.EntityId] = wmtypes.EntityStateDisabled
+			delete(s.emails, in.EntityId)
+		}
+		return map[string]any{}, ""
+	case "UpdatePrimaryEmailAddress":
+		s.emails[in.EntityId] = in.Email
+		return map[string]any{}, ""
+	case "DeleteAlias":
+		return map[string]any{}, ""
+	case "ListGroupMembers":
+		members := []map[string]string{}
+		if nested, ok := s.nested[in.GroupId]; ok {
+			for _, id := range nested {
+				members = append(members, map[string]string{"Id": id, "Type": "GROUP", "State": "ENABLED"})
+			}
+			return map[string]any{"Members": members}, ""
+		}
+		for id := range s.members {
+			members = append(members, map[string]string{"Id": id, "Type": "USER", "State": "ENABLED"})
+		}
+		return map[string]any{"Members": members}, ""
+	case "AssociateMemberToGroup", "DisassociateMemberFromGroup":
+		if errType := s.failures[in.MemberId]; errType != "" {
+			return nil, errType
+		}
+		s.members[in.MemberId] = op == "AssociateMemberToGroup"
+		if !s.members[in.MemberId] {
+			delete(s.members, in.MemberId)
+		}
+		return map[string]any{}, ""
+	}
+	return nil, "InvalidParameterException"
+}
+
+func (s *stubWorkMail) memberIDs() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ids := make([]string, 0, len(s.members))
+	for id := range s.members {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// diagSummaries returns the summary and detail of each diagnostic of the
+// given severity.
+func diagSummaries(diags diag.Diagnostics, severity diag.Severity) []string {
+	var out []string
+	for _, d := range diags {
+		if d.Severity() == severity {
+			out = append(out, d.Summary()+": "+d.Detail())
+		}
+	}
+	return out
+}
+
+func TestReconcileGroupMembers_aggregatesErrors(t *testing.T) {
+	stub := newStubWorkMail(map[string]wmtypes.EntityState{
+		"u-1":   wmtypes.EntityStateEnabled,
+		"u-2":   wmtypes.EntityStateEnabled,
+		"u-bad": wmtypes.EntityStateEnabled,
+		"u-old": wmtypes.EntityStateEnabled,
+		"u-pin": wmtypes.EntityStateEnabled,
+	}, "u-old", "u-pin")
+	stub.failures["u-bad"] = "EntityStateException"
+	stub.failures["u-pin"] = "UnsupportedOperationException"
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	diags := reconcileGroupMembers(ctx, stub.client(t), "m-1", "g-1", []string{"u-1", "u-2", "u-bad", "u-gone"}, []string{"u-old", "u-pin"})
+
+	errs := diagSummaries(diags, diag.SeverityError)
+	if len(errs) != 3 {
+		t.Fatalf("errors = %q, want one each for u-gone, u-pin and u-bad", errs)
+	}
+	for i, want := range []string{"Unknown group member: No user, group or resource with ID u-gone", "Error removing member from group: Member u-pin", "Error adding member to group: Member u-bad"} {
+		if !strings.HasPrefix(errs[i], want) {
+			t.Errorf("error %d = %q, want prefix %q", i, errs[i], want)
+		}
+	}
+	if got, want := stub.memberIDs(), []string{"u-1", "u-2", "u-pin"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("members = %v, want %v", got, want)
+	}
+	if stub.calls["AssociateMemberToGroup"] != 3 {
+		t.Errorf("AssociateMemberToGroup called %d times, want 3 (unknown members are not added)", stub.calls["AssociateMemberToGroup"])
+	}
+}
+
+func TestReconcileGroupMembers_notEnabledMemberDoesNotBlockOthers(t *testing.T) {
+	stub := newStubWorkMail(map[string]wmtypes.EntityState{
+		"u-1":        wmtypes.EntityStateEnabled,
+		"u-disabled": wmtypes.EntityStateDisabled,
+		"u-old":      wmtypes.EntityStateEnabled,
+	}, "u-old")
+	stub.failures["u-disabled"] = "EntityStateException"
+
+	// The disabled member never becomes enabled; the wait for it must leave
+	// time for the other changes.
+	ctx, cancel := context.WithTimeout(context.Background(), 400*time.Millisecond)
+	defer cancel()
+	diags := reconcileGroupMembers(ctx, stub.client(t), "m-1", "g-1", []string{"u-1", "u-disabled"}, []string{"u-old"})
+
+	if warnings := diagSummaries(diags, diag.SeverityWarning); len(warnings) != 1 || !strings.Contains(warnings[0], "u-disabled") {
+		t.Errorf("warnings = %q, want one naming u-disabled", warnings)
+	}
+	if errs := diagSummaries(diags, diag.SeverityError); len(errs) != 1 || !strings.Contains(errs[0], "Member u-disabled") {
+		t.Errorf("errors = %q, want one for u-disabled", errs)
+	}
+	if got, want := stub.memberIDs(), []string{"u-1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("members = %v, want %v", got, want)
+	}
+}
+
+func TestReconcileGroupMembers_boundedConcurrency(t *testing.T) {
+	users := map[string]wmtypes.EntityState{}
+	var add []string
+	for i := 0; i < 3*memberWorkers; i++ {
+		id := "u-" + string(rune('a'+i))
+		users[id] = wmtypes.EntityStateEnabled
+		add = append(add, id)
+	}
+	stub := newStubWorkMail(users)
+	stub.delay = 20 * time.Millisecond
+
+	diags := reconcileGroupMembers(context.Background(), stub.client(t), "m-1", "g-1", add, nil)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if len(stub.memberIDs()) != len(add) {
+		t.Errorf("added %d members, want %d", len(stub.memberIDs()), len(add))
+	}
+	if stub.maxInFlight > memberWorkers || stub.maxInFlight < 2 {
+		t.Errorf("max concurrent membership calls = %d, want between 2 and %d", stub.maxInFlight, memberWorkers)
+	}
+	if stub.calls["ListUsers"] != 1 || stub.calls["DescribeUser"] != 0 {
+		t.Errorf("readiness used %d ListUsers and %d DescribeUser calls, want one listing", stub.calls["ListUsers"], stub.calls["DescribeUser"])
+	}
+}
+
+func TestRunMemberChanges_expiredContext(t *testing.T) {
+	stub := newStubWorkMail(map[string]wmtypes.EntityState{"u-1": wmtypes.EntityStateEnabled})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	errs := runMemberChanges(ctx, stub.client(t), "m-1", "g-1", []memberChange{{memberID: "u-1"}}, make(chan struct{}, 1))
+	if len(errs) != 1 || errs[0] == nil || !strings.Contains(errs[0].Error(), "not attempted") {
+		t.Errorf("errs = %v, want the change not attempted", errs)
+	}
+	if stub.calls["AssociateMemberToGroup"] != 0 {
+		t.Error("AssociateMemberToGroup was called with an expired context")
+	}
+}
+
+func TestApplyGroupMemberChanges_readsBackPartialResult(t *testing.T) {
+	stub := newStubWorkMail(map[string]wmtypes.EntityState{
+		"u-1":   wmtypes.EntityStateEnabled,
+		"u-bad": wmtypes.EntityStateEnabled,
+	})
+	stub.failures["u-bad"] = "EntityStateException"
+
+	ctx := context.Background()
+	planned, _ := types.SetValueFrom(ctx, types.StringType, []string{"u-1", "u-bad"})
+	data := groupResourceModel{
+		ID:             types.StringValue("g-1"),
+		OrganizationID: types.StringValue("m-1"),
+		Members:        planned,
+	}
+	diags := applyGroupMemberChanges(ctx, stub.client(t), &data, time.Second, []string{"u-1", "u-bad"}, nil)
+	if !diags.HasError() {
+		t.Fatal("expected an error for u-bad")
+	}
+
+	var members []string
+	data.Members.ElementsAs(ctx, &members, false)
+	if !reflect.DeepEqual(members, []string{"u-1"}) {
+		t.Errorf("members in state = %v, want the applied member only", members)
+	}
+}
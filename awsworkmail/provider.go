@@ -32,10 +32,22 @@ type AwsWorkMailProvider struct {
 
 // AwsWorkMailProviderModel describes the provider data model.
 type AwsWorkMailProviderModel struct {
-	Endpoint   types.String `tfsdk:"endpoint"`
-	Region     types.String `tfsdk:"region"`
-	AssumeRole types.Object `tfsdk:"assume_role"`
-	Profile    types.String `tfsdk:"profile"`
+	Endpoint    types.String `tfsdk:"endpoint"`
+	Region      types.String `tfsdk:"region"`
+	AssumeRole  types.Object `tfsdk:"assume_role"`
+	Profile     types.String `tfsdk:"profile"`
+	DefaultTags types.Object `tfsdk:"default_tags"`
+}
+
+// DefaultTagsModel describes the default_tags configuration.
+type DefaultTagsModel struct {
+	Tags types.Map `tfsdk:"tags"`
+}
+
+// providerData is handed to resources and data sources on Configure.
+type providerData struct {
+	cfg         aws.Config
+	defaultTags map[string]string
 }
 
 // AssumeRoleModel describes the assume_role configuration.
@@ -89,6 +101,18 @@ func (p *AwsWorkMailProvider) Schema(ctx context.Context, req provider.SchemaReq
 				},
 			},
 		},
+		Blocks: map[string]pschema.Block{
+			"default_tags": pschema.SingleNestedBlock{
+				MarkdownDescription: "Configuration block with tags applied to every taggable resource managed by the provider. Tags set on a resource override default tags with the same key.",
+				Attributes: map[string]pschema.Attribute{
+					"tags": pschema.MapAttribute{
+						ElementType:         types.StringType,
+						MarkdownDescription: "Tags to apply to all taggable resources.",
+						Optional:            true,
+					},
+				},
+			},
+		},
 	}
 }
 
@@ -137,9 +161,29 @@ func (p *AwsWorkMailProvider) Configure(ctx context.Context, req provider.Config
 		}
 	}
 
-	// Pass AWS config to resources and data sources
-	resp.DataSourceData = cfg
-	resp.ResourceData = cfg
+	// Handle default_tags if configured
+	defaultTags := map[string]string{}
+	if !data.DefaultTags.IsNull() {
+		var defaultTagsConfig DefaultTagsModel
+		resp.Diagnostics.Append(data.DefaultTags.As(ctx, &defaultTagsConfig, basetypes.ObjectAsOptions{})...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if !defaultTagsConfig.Tags.IsNull() {
+			resp.Diagnostics.Append(defaultTagsConfig.Tags.ElementsAs(ctx, &defaultTags, false)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+	}
+
+	// Pass AWS config and default tags to resources and data sources
+	pd := &providerData{
+		cfg:         cfg,
+		defaultTags: defaultTags,
+	}
+	resp.DataSourceData = pd
+	resp.ResourceData = pd
 }
 
 // assumeRole handles the STS AssumeRole operation
@@ -189,12 +233,19 @@ func (p *AwsWorkMailProvider) Resources(ctx context.Context) []func() resource.R
 		NewUserResource,
 		NewGroupResource,
 		NewDomainResource,
+		NewDefaultDomainResource,
+		NewAliasResource,
 	}
 }
 
 func (p *AwsWorkMailProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewUserDataSource, // Register the new user data source
+		NewOrganizationDataSource,
+		NewOrganizationsDataSource,
+		NewDomainDataSource,
+		NewDomainsDataSource,
+		NewDomainDNSCheckDataSource,
 	}
 }
 
@@ -0,0 +1,230 @@
+package awsworkmail
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/workmail"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// awsworkmail_alias resource: manages a single email alias of a user, group
+// or resource. It only ever touches its own alias, so several teams can add
+// aliases to the same entity.
+
+// Ensure implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &aliasResource{}
+	_ resource.ResourceWithImportState    = &aliasResource{}
+	_ resource.ResourceWithValidateConfig = &aliasResource{}
+)
+
+// aliasResourceModel describes the resource data model.
+type aliasResourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	OrganizationID types.String `tfsdk:"organization_id"`
+	EntityID       types.String `tfsdk:"entity_id"`
+	Alias          types.String `tfsdk:"alias"`
+}
+
+type aliasResource struct {
+	cfg aws.Config
+}
+
+func NewAliasResource() resource.Resource {
+	return &aliasResource{}
+}
+
+func (r *aliasResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_alias"
+}
+
+func (r *aliasResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "ID of the resource (`<organization_id>,<entity_id>,<alias>`)",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"organization_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "ID of the WorkMail organization",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"entity_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "ID of the user, group or resource that receives the alias",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"alias": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Email alias, on a domain registered with the organization",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *aliasResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	pd, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.cfg = pd.cfg
+}
+
+func (r *aliasResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var alias types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("alias"), &alias)...)
+	if resp.Diagnostics.HasError() || alias.IsNull() || alias.IsUnknown() {
+		return
+	}
+	if aliasDomain(alias.ValueString()) == "" {
+		resp.Diagnostics.AddAttributeError(path.Root("alias"), "Invalid alias", fmt.Sprintf("%q is not an email address.", alias.ValueString()))
+	}
+}
+
+func (r *aliasResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data aliasResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := workmail.NewFromConfig(r.cfg)
+	orgID := data.OrganizationID.ValueString()
+
+	missing, err := unregisteredAliasDomains(ctx, client, orgID, []string{data.Alias.ValueString()})
+	if err != nil {
+		resp.Diagnostics.AddError("Error listing WorkMail mail domains", err.Error())
+		return
+	}
+	if len(missing) > 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("alias"),
+			"Alias domain not registered",
+			fmt.Sprintf("The domain of %s is not registered with organization %s.", data.Alias.ValueString(), orgID),
+		)
+		return
+	}
+
+	_, err = client.CreateAlias(ctx, &workmail.CreateAliasInput{
+		OrganizationId: aws.String(orgID),
+		EntityId:       aws.String(data.EntityID.ValueString()),
+		Alias:          aws.String(data.Alias.ValueString()),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating WorkMail alias", err.Error())
+		return
+	}
+
+	data.ID = types.StringValue(aliasResourceID(orgID, data.EntityID.ValueString(), data.Alias.ValueString()))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *aliasResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data aliasResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := workmail.NewFromConfig(r.cfg)
+
+	aliases, err := listAliases(ctx, client, data.OrganizationID.ValueString(), data.EntityID.ValueString())
+	if err != nil && !isEntityNotFound(err) {
+		resp.Diagnostics.AddError("Error reading WorkMail aliases", err.Error())
+		return
+	}
+	for _, alias := range aliases {
+		if strings.EqualFold(alias, data.Alias.ValueString()) {
+			data.ID = types.StringValue(aliasResourceID(data.OrganizationID.ValueString(), data.EntityID.ValueString(), data.Alias.ValueString()))
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			return
+		}
+	}
+
+	// The alias or its entity was removed outside Terraform.
+	resp.Diagnostics.AddWarning(
+		"WorkMail alias not found",
+		"Alias "+data.Alias.ValueString()+" no longer exists on entity "+data.EntityID.ValueString()+" and has been removed from state.",
+	)
+	resp.State.RemoveResource(ctx)
+}
+
+// Update is never called, since every attribute forces replacement.
+func (r *aliasResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data aliasResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete removes only the alias owned by this resource.
+func (r *aliasResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data aliasResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := workmail.NewFromConfig(r.cfg)
+
+	_, err := client.DeleteAlias(ctx, &workmail.DeleteAliasInput{
+		OrganizationId: aws.String(data.OrganizationID.ValueString()),
+		EntityId:       aws.String(data.EntityID.ValueString()),
+		Alias:          aws.String(data.Alias.ValueString()),
+	})
+	if err != nil && !isEntityNotFound(err) {
+		resp.Diagnostics.AddError("Error deleting WorkMail alias", err.Error())
+	}
+}
+
+func (r *aliasResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, ",")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import ID format",
+			"Expected import ID format: <organization_id>,<entity_id>,<alias>",
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("organization_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("entity_id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("alias"), parts[2])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+}
+
+// aliasResourceID builds the ID of an awsworkmail_alias resource.
+func aliasResourceID(organizationID, entityID, alias string) string {
+	return organizationID + "," + entityID + "," + alias
+}
@@ -0,0 +1,49 @@
+package awsworkmail
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccAlias_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAliasConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awsworkmail_alias.test", "alias", "campaign@tfacc-alias.awsapps.com"),
+					resource.TestCheckResourceAttrPair("awsworkmail_alias.test", "entity_id", "awsworkmail_user.test", "id"),
+				),
+			},
+			{
+				ResourceName:      "awsworkmail_alias.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccAliasConfig() string {
+	return `
+resource "awsworkmail_organization" "test" {
+  alias = "tfacc-alias"
+}
+
+resource "awsworkmail_user" "test" {
+  organization_id = awsworkmail_organization.test.id
+  name            = "tfacc.alias"
+  display_name    = "Tfacc Alias"
+  password        = "ChangeMe123!"
+}
+
+resource "awsworkmail_alias" "test" {
+  organization_id = awsworkmail_organization.test.id
+  entity_id       = awsworkmail_user.test.id
+  alias           = "campaign@${awsworkmail_organization.test.alias}.awsapps.com"
+}
+`
+}
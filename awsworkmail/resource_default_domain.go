@@ -0,0 +1,213 @@
+package awsworkmail
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/workmail"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// testMailDomainSuffix is the suffix of the test domain WorkMail creates for
+// every organization (<alias>.awsapps.com).
+const testMailDomainSuffix = ".awsapps.com"
+
+// defaultDomainResourceModel describes the resource data model.
+type defaultDomainResourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	OrganizationID types.String `tfsdk:"organization_id"`
+	DomainName     types.String `tfsdk:"domain_name"`
+}
+
+type defaultDomainResource struct {
+	cfg aws.Config
+}
+
+func NewDefaultDomainResource() resource.Resource {
+	return &defaultDomainResource{}
+}
+
+func (r *defaultDomainResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_default_domain"
+}
+
+func (r *defaultDomainResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "ID of the resource (organization ID)",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"organization_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "ID of the WorkMail organization",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"domain_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Registered mail domain to use as the default domain of the organization. New users get an address on this domain.",
+			},
+		},
+	}
+}
+
+func (r *defaultDomainResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	pd, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.cfg = pd.cfg
+}
+
+func (r *defaultDomainResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data defaultDomainResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := workmail.NewFromConfig(r.cfg)
+
+	_, err := client.UpdateDefaultMailDomain(ctx, &workmail.UpdateDefaultMailDomainInput{
+		OrganizationId: aws.String(data.OrganizationID.ValueString()),
+		DomainName:     aws.String(data.DomainName.ValueString()),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Error setting WorkMail default mail domain", err.Error())
+		return
+	}
+
+	data.ID = data.OrganizationID
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *defaultDomainResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data defaultDomainResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := workmail.NewFromConfig(r.cfg)
+
+	out, err := client.DescribeOrganization(ctx, &workmail.DescribeOrganizationInput{
+		OrganizationId: aws.String(data.OrganizationID.ValueString()),
+	})
+	if err != nil {
+		// If organization is not found, remove from state
+		if strings.Contains(err.Error(), "OrganizationNotFoundException") {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Error reading WorkMail organization", err.Error())
+		return
+	}
+
+	data.ID = data.OrganizationID
+	if out.DefaultMailDomain != nil {
+		data.DomainName = types.StringValue(*out.DefaultMailDomain)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *defaultDomainResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data defaultDomainResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := workmail.NewFromConfig(r.cfg)
+
+	_, err := client.UpdateDefaultMailDomain(ctx, &workmail.UpdateDefaultMailDomainInput{
+		OrganizationId: aws.String(data.OrganizationID.ValueString()),
+		DomainName:     aws.String(data.DomainName.ValueString()),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating WorkMail default mail domain", err.Error())
+		return
+	}
+
+	data.ID = data.OrganizationID
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete points the organization back at its awsapps.com test domain, since an
+// organization always has a default domain.
+func (r *defaultDomainResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data defaultDomainResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := workmail.NewFromConfig(r.cfg)
+
+	testDomain, err := findTestMailDomain(ctx, client, data.OrganizationID.ValueString())
+	if err != nil {
+		// If organization is already gone, that's fine
+		if !strings.Contains(err.Error(), "OrganizationNotFoundException") {
+			resp.Diagnostics.AddError("Error finding WorkMail test mail domain", err.Error())
+		}
+		return
+	}
+	if testDomain == "" {
+		resp.Diagnostics.AddWarning(
+			"WorkMail test mail domain not found",
+			"No "+testMailDomainSuffix+" domain is registered with organization "+data.OrganizationID.ValueString()+". The default mail domain was left unchanged.",
+		)
+		return
+	}
+
+	_, err = client.UpdateDefaultMailDomain(ctx, &workmail.UpdateDefaultMailDomainInput{
+		OrganizationId: aws.String(data.OrganizationID.ValueString()),
+		DomainName:     aws.String(testDomain),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Error restoring WorkMail default mail domain", err.Error())
+	}
+}
+
+func (r *defaultDomainResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("organization_id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+}
+
+// findTestMailDomain returns the awsapps.com test domain of the organization,
+// or an empty string if there is none.
+func findTestMailDomain(ctx context.Context, client *workmail.Client, organizationID string) (string, error) {
+	domains, err := listMailDomains(ctx, client, organizationID)
+	if err != nil {
+		return "", err
+	}
+	for _, domain := range domains {
+		if strings.HasSuffix(aws.ToString(domain.DomainName), testMailDomainSuffix) {
+			return *domain.DomainName, nil
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,53 @@
+package awsworkmail
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDefaultDomain_basic(t *testing.T) {
+	domain := os.Getenv("TF_AWSWORKMAIL_VERIFIED_DOMAIN")
+	if domain == "" {
+		t.Skip("TF_AWSWORKMAIL_VERIFIED_DOMAIN must be set to a domain that WorkMail can verify")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDefaultDomainConfig(domain),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awsworkmail_default_domain.test", "domain_name", domain),
+					resource.TestCheckResourceAttrPair("awsworkmail_default_domain.test", "id", "awsworkmail_organization.test", "id"),
+				),
+			},
+			{
+				ResourceName:      "awsworkmail_default_domain.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccDefaultDomainConfig(domain string) string {
+	return fmt.Sprintf(`
+resource "awsworkmail_organization" "test" {
+  alias = "tfacc-default-domain"
+}
+
+resource "awsworkmail_domain" "test" {
+  organization_id = awsworkmail_organization.test.id
+  domain          = "%s"
+}
+
+resource "awsworkmail_default_domain" "test" {
+  organization_id = awsworkmail_organization.test.id
+  domain_name     = awsworkmail_domain.test.domain
+}
+`, domain)
+}
@@ -4,12 +4,19 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/workmail"
+	wmtypes "github.com/aws/aws-sdk-go-v2/service/workmail/types"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -19,8 +26,33 @@ type domainResourceModel struct {
 	OrganizationID types.String `tfsdk:"organization_id"`
 	Domain         types.String `tfsdk:"domain"`
 	MXRecords      types.List   `tfsdk:"mx_records"`
+	Records        types.List   `tfsdk:"records"`
+
+	OwnershipVerificationStatus types.String   `tfsdk:"ownership_verification_status"`
+	DkimVerificationStatus      types.String   `tfsdk:"dkim_verification_status"`
+	WaitForVerification         types.Bool     `tfsdk:"wait_for_verification"`
+	ForceDestroy                types.Bool     `tfsdk:"force_destroy"`
+	Timeouts                    timeouts.Value `tfsdk:"timeouts"`
+}
+
+const (
+	// defaultDomainVerificationTimeout bounds wait_for_verification when no
+	// create timeout is configured.
+	defaultDomainVerificationTimeout = 30 * time.Minute
+	// domainVerificationPollInterval is the delay between GetMailDomain polls.
+	domainVerificationPollInterval = 30 * time.Second
+)
+
+// dnsRecordAttrTypes describes an element of the records attribute.
+var dnsRecordAttrTypes = map[string]attr.Type{
+	"type":     types.StringType,
+	"hostname": types.StringType,
+	"value":    types.StringType,
 }
 
+// Ensure domainResource checks domain usage when planning a destroy.
+var _ resource.ResourceWithModifyPlan = &domainResource{}
+
 type domainResource struct {
 	cfg aws.Config
 }
@@ -33,7 +65,7 @@ func (r *domainResource) Metadata(_ context.Context, req resource.MetadataReques
 	resp.TypeName = req.ProviderTypeName + "_domain"
 }
 
-func (r *domainResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *domainResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -43,16 +75,63 @@ func (r *domainResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 			"organization_id": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "ID of the WorkMail organization",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"domain": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "Domain name to add to WorkMail organization",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"mx_records": schema.ListAttribute{
 				ElementType:         types.StringType,
 				Computed:            true,
 				MarkdownDescription: "List of MX records to configure in your DNS for this domain.",
 			},
+			"records": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "All DNS records to configure for this domain: the TXT ownership record, DKIM CNAMEs, MX, SPF and autodiscover.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "DNS record type (for example `MX`, `TXT` or `CNAME`)",
+						},
+						"hostname": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Fully qualified name of the DNS record",
+						},
+						"value": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Value of the DNS record",
+						},
+					},
+				},
+			},
+			"ownership_verification_status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Status of the domain ownership (TXT record) verification: `PENDING`, `VERIFIED` or `FAILED`.",
+			},
+			"dkim_verification_status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Status of the DKIM (CNAME records) verification: `PENDING`, `VERIFIED` or `FAILED`.",
+			},
+			"wait_for_verification": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Wait during creation until both ownership and DKIM verification are `VERIFIED`. The wait is bounded by the `create` timeout (default 30 minutes).",
+			},
+			"force_destroy": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "On destroy, move primary addresses of users, groups and resources on this domain to the organization's default domain and delete aliases on this domain before deregistering it. If the domain is the default domain, the organization falls back to its awsapps.com test domain first. Defaults to `false`, in which case destroying a domain still in use fails.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+			}),
 		},
 	}
 }
@@ -62,16 +141,16 @@ func (r *domainResource) Configure(_ context.Context, req resource.ConfigureRequ
 		return
 	}
 
-	cfg, ok := req.ProviderData.(aws.Config)
+	pd, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected aws.Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.cfg = cfg
+	r.cfg = pd.cfg
 }
 
 func (r *domainResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -108,21 +187,41 @@ func (r *domainResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	var mxRecords []string
-	for _, record := range domainOutput.Records {
-		if record.Type != nil && *record.Type == "MX" && record.Value != nil {
-			mxRecords = append(mxRecords, *record.Value)
+	var waitErr error
+	if data.WaitForVerification.ValueBool() {
+		createTimeout, diags := data.Timeouts.Create(ctx, defaultDomainVerificationTimeout)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		var waitOutput *workmail.GetMailDomainOutput
+		waitOutput, waitErr = waitForDomainVerification(ctx, client, getDomainInput, createTimeout)
+		if waitOutput != nil {
+			domainOutput = waitOutput
 		}
 	}
 
-	mxRecordsList, diags := types.ListValueFrom(ctx, types.StringType, mxRecords)
-	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setDomainDetails(ctx, &data, domainOutput)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	data.MXRecords = mxRecordsList
 
+	// Save state even if verification did not complete so the registered
+	// domain is tracked (and tainted) rather than orphaned.
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	if waitErr != nil {
+		resp.Diagnostics.AddError(
+			"WorkMail domain not verified",
+			fmt.Sprintf("Domain %s was registered but is not verified (ownership: %s, DKIM: %s): %s\n\nMake sure the following DNS records exist:\n%s",
+				data.Domain.ValueString(),
+				domainOutput.OwnershipVerificationStatus,
+				domainOutput.DkimVerificationStatus,
+				waitErr,
+				strings.Join(unverifiedDomainRecords(domainOutput), "\n"),
+			),
+		)
+	}
 }
 
 func (r *domainResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -142,8 +241,12 @@ func (r *domainResource) Read(ctx context.Context, req resource.ReadRequest, res
 
 	output, err := client.GetMailDomain(ctx, input)
 	if err != nil {
-		// If domain is not found, remove from state
-		if strings.Contains(err.Error(), "EntityNotFoundException") {
+		// If domain or organization is not found, remove from state
+		if isMailDomainNotFound(err) {
+			resp.Diagnostics.AddWarning(
+				"WorkMail domain not found",
+				"Domain "+data.Domain.ValueString()+" is no longer registered with organization "+data.OrganizationID.ValueString()+" and has been removed from state.",
+			)
 			resp.State.RemoveResource(ctx)
 			return
 		}
@@ -151,20 +254,11 @@ func (r *domainResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
-	// Update MX records
-	var mxRecords []string
-	for _, record := range output.Records {
-		if record.Type != nil && *record.Type == "MX" && record.Value != nil {
-			mxRecords = append(mxRecords, *record.Value)
-		}
-	}
-
-	mxRecordsList, diags := types.ListValueFrom(ctx, types.StringType, mxRecords)
-	resp.Diagnostics.Append(diags...)
+	// Update DNS records
+	resp.Diagnostics.Append(setDomainDetails(ctx, &data, output)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	data.MXRecords = mxRecordsList
 
 	// Save the state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -179,6 +273,16 @@ func (r *domainResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 	// For domains, updates are mostly read-only operations
 	// The domain name itself cannot be changed, only other attributes
+	var state domainResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.ID = state.ID
+	data.MXRecords = state.MXRecords
+	data.Records = state.Records
+	data.OwnershipVerificationStatus = state.OwnershipVerificationStatus
+	data.DkimVerificationStatus = state.DkimVerificationStatus
 
 	// Save the updated state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -193,21 +297,153 @@ func (r *domainResource) Delete(ctx context.Context, req resource.DeleteRequest,
 
 	client := workmail.NewFromConfig(r.cfg)
 
+	orgID := data.OrganizationID.ValueString()
+	domain := data.Domain.ValueString()
+
+	// WorkMail refuses to deregister the default domain or a domain still
+	// used by entity addresses, so check first and report what is in the way.
+	domainOutput, err := client.GetMailDomain(ctx, &workmail.GetMailDomainInput{
+		OrganizationId: aws.String(orgID),
+		DomainName:     aws.String(domain),
+	})
+	if err != nil {
+		// If domain is already gone, that's fine
+		if !isMailDomainNotFound(err) {
+			resp.Diagnostics.AddError("Error reading WorkMail domain", err.Error())
+		}
+		return
+	}
+	addresses, err := listEntityAddresses(ctx, client, orgID, true)
+	if err != nil {
+		resp.Diagnostics.AddError("Error checking WorkMail domain usage", err.Error())
+		return
+	}
+	usage := usageOnDomain(addresses, domain)
+
+	if domainOutput.IsDefault || len(usage) > 0 {
+		if !data.ForceDestroy.ValueBool() {
+			resp.Diagnostics.AddError("WorkMail domain is in use", domainInUseDetail(domain, domainOutput.IsDefault, usage)+"\n\nMove these addresses to another domain, or set force_destroy = true to do so automatically.")
+			return
+		}
+
+		targetDomain, err := releaseTargetDomain(ctx, client, orgID, domainOutput.IsDefault)
+		if err != nil {
+			resp.Diagnostics.AddError("Error finding WorkMail mail domain to move addresses to", err.Error())
+			return
+		}
+		if targetDomain == "" {
+			resp.Diagnostics.AddError("WorkMail domain is in use", "Domain "+domain+" is the default mail domain and no "+testMailDomainSuffix+" domain is available to fall back to.")
+			return
+		}
+		// Check every address before changing anything, so that a collision
+		// does not leave the domain half released.
+		if conflicts := releaseConflicts(addresses, usage, targetDomain); len(conflicts) > 0 {
+			resp.Diagnostics.AddError("WorkMail address conflict", "Addresses on "+domain+" cannot be moved to "+targetDomain+":\n  - "+strings.Join(conflicts, "\n  - "))
+			return
+		}
+
+		if domainOutput.IsDefault {
+			_, err = client.UpdateDefaultMailDomain(ctx, &workmail.UpdateDefaultMailDomainInput{
+				OrganizationId: aws.String(orgID),
+				DomainName:     aws.String(targetDomain),
+			})
+			if err != nil {
+				resp.Diagnostics.AddError("Error changing WorkMail default mail domain", err.Error())
+				return
+			}
+		}
+
+		if err := releaseDomain(ctx, client, orgID, targetDomain, usage); err != nil {
+			resp.Diagnostics.AddError("Error moving addresses off WorkMail domain", err.Error())
+			return
+		}
+	}
+
 	// Deregister the domain from WorkMail
 	input := &workmail.DeregisterMailDomainInput{
 		OrganizationId: aws.String(data.OrganizationID.ValueString()),
 		DomainName:     aws.String(data.Domain.ValueString()),
 	}
 
-	_, err := client.DeregisterMailDomain(ctx, input)
+	_, err = client.DeregisterMailDomain(ctx, input)
 	if err != nil {
 		// If domain is already gone, that's fine
-		if !strings.Contains(err.Error(), "EntityNotFoundException") {
+		if !isMailDomainNotFound(err) {
 			resp.Diagnostics.AddError("Error deregistering WorkMail domain", err.Error())
 		}
 	}
 }
 
+// ModifyPlan warns at plan time when a domain about to be destroyed is still
+// the default domain or used by entity addresses. Entities destroyed in the
+// same run release their addresses first, so this is not an error; Delete
+// performs the authoritative check.
+func (r *domainResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if !req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var data domainResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := workmail.NewFromConfig(r.cfg)
+
+	domain := data.Domain.ValueString()
+	domainOutput, err := client.GetMailDomain(ctx, &workmail.GetMailDomainInput{
+		OrganizationId: aws.String(data.OrganizationID.ValueString()),
+		DomainName:     aws.String(domain),
+	})
+	if err != nil {
+		return
+	}
+	// Listing aliases costs one call per entity, so a plain destroy plan only
+	// checks primary addresses; aliases are checked when the domain is
+	// destroyed, or at plan time with force_destroy.
+	forceDestroy := data.ForceDestroy.ValueBool()
+	addresses, err := listEntityAddresses(ctx, client, data.OrganizationID.ValueString(), forceDestroy)
+	if err != nil {
+		resp.Diagnostics.AddWarning("Unable to check WorkMail domain usage", err.Error())
+		return
+	}
+	usage := usageOnDomain(addresses, domain)
+	if !domainOutput.IsDefault && len(usage) == 0 {
+		return
+	}
+
+	detail := domainInUseDetail(domain, domainOutput.IsDefault, usage)
+	if !forceDestroy {
+		detail += "\n\nUnless these entities are destroyed first in the same run, deregistration will fail. Aliases on the domain are not listed here and also block deregistration. Set force_destroy = true to move them automatically."
+		resp.Diagnostics.AddWarning("WorkMail domain is in use", detail)
+		return
+	}
+
+	detail += "\n\nforce_destroy is set: these addresses will be moved to the default domain before the domain is deregistered."
+	resp.Diagnostics.AddWarning("WorkMail domain is in use", detail)
+
+	targetDomain, err := releaseTargetDomain(ctx, client, data.OrganizationID.ValueString(), domainOutput.IsDefault)
+	if err != nil || targetDomain == "" {
+		return
+	}
+	if conflicts := releaseConflicts(addresses, usage, targetDomain); len(conflicts) > 0 {
+		resp.Diagnostics.AddWarning("WorkMail address conflict", "Destroying this domain will fail, because addresses on "+domain+" cannot be moved to "+targetDomain+":\n  - "+strings.Join(conflicts, "\n  - "))
+	}
+}
+
+// domainInUseDetail describes why a domain cannot be deregistered.
+func domainInUseDetail(domain string, isDefault bool, usage []domainUsage) string {
+	var parts []string
+	if isDefault {
+		parts = append(parts, "Domain "+domain+" is the default mail domain of the organization.")
+	}
+	if len(usage) > 0 {
+		parts = append(parts, fmt.Sprintf("Domain %s is used by %d address(es):\n%s", domain, len(usage), formatDomainUsage(usage)))
+	}
+	return strings.Join(parts, "\n\n")
+}
+
 func (r *domainResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	parts := strings.Split(req.ID, ",")
 	if len(parts) != 2 {
@@ -218,5 +454,119 @@ func (r *domainResource) ImportState(ctx context.Context, req resource.ImportSta
 		return
 	}
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("organization_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("domain"), parts[1])...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
 }
+
+// isMailDomainNotFound reports whether err means the domain, or the
+// organization it belonged to, no longer exists.
+func isMailDomainNotFound(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "MailDomainNotFoundException") ||
+		strings.Contains(msg, "EntityNotFoundException") ||
+		strings.Contains(msg, "OrganizationNotFoundException")
+}
+
+// setDomainDetails stores the DNS records and verification statuses returned
+// by GetMailDomain. MX values are also exposed in mx_records.
+func setDomainDetails(ctx context.Context, data *domainResourceModel, out *workmail.GetMailDomainOutput) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var mxRecords []string
+	for _, record := range out.Records {
+		if record.Type != nil && *record.Type == "MX" && record.Value != nil {
+			mxRecords = append(mxRecords, *record.Value)
+		}
+	}
+
+	mxRecordsList, d := types.ListValueFrom(ctx, types.StringType, mxRecords)
+	diags.Append(d...)
+	recordsList, d := dnsRecordsListValue(out.Records)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+	data.MXRecords = mxRecordsList
+	data.Records = recordsList
+	data.OwnershipVerificationStatus = types.StringValue(string(out.OwnershipVerificationStatus))
+	data.DkimVerificationStatus = types.StringValue(string(out.DkimVerificationStatus))
+
+	return diags
+}
+
+// isDomainVerified reports whether both ownership and DKIM are verified.
+func isDomainVerified(out *workmail.GetMailDomainOutput) bool {
+	return out.OwnershipVerificationStatus == wmtypes.DnsRecordVerificationStatusVerified &&
+		out.DkimVerificationStatus == wmtypes.DnsRecordVerificationStatusVerified
+}
+
+// waitForDomainVerification polls GetMailDomain until the domain is verified
+// or the timeout expires. The last successful output is always returned so
+// callers can report which records are still missing.
+func waitForDomainVerification(ctx context.Context, client *workmail.Client, input *workmail.GetMailDomainInput, timeout time.Duration) (*workmail.GetMailDomainOutput, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var last *workmail.GetMailDomainOutput
+	for {
+		out, err := client.GetMailDomain(ctx, input)
+		if err == nil {
+			last = out
+			if isDomainVerified(out) {
+				return last, nil
+			}
+		} else if ctx.Err() == nil {
+			return last, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return last, fmt.Errorf("timed out after %s waiting for verification", timeout)
+		case <-time.After(domainVerificationPollInterval):
+		}
+	}
+}
+
+// unverifiedDomainRecords lists the DNS records backing each verification
+// that has not completed yet: the TXT ownership record and the DKIM CNAMEs.
+func unverifiedDomainRecords(out *workmail.GetMailDomainOutput) []string {
+	var missing []string
+	for _, record := range out.Records {
+		recordType := aws.ToString(record.Type)
+		hostname := aws.ToString(record.Hostname)
+		ownership := recordType == "TXT" && strings.HasPrefix(hostname, "_amazonses.")
+		dkim := recordType == "CNAME" && strings.Contains(hostname, "._domainkey.")
+		if (ownership && out.OwnershipVerificationStatus != wmtypes.DnsRecordVerificationStatusVerified) ||
+			(dkim && out.DkimVerificationStatus != wmtypes.DnsRecordVerificationStatusVerified) {
+			missing = append(missing, fmt.Sprintf("  %s %s %s", recordType, hostname, aws.ToString(record.Value)))
+		}
+	}
+	return missing
+}
+
+// dnsRecordsListValue converts WorkMail DNS records into a list of objects
+// matching dnsRecordAttrTypes.
+func dnsRecordsListValue(records []wmtypes.DnsRecord) (types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	values := make([]attr.Value, 0, len(records))
+	for _, record := range records {
+		obj, d := types.ObjectValue(dnsRecordAttrTypes, map[string]attr.Value{
+			"type":     types.StringPointerValue(record.Type),
+			"hostname": types.StringPointerValue(record.Hostname),
+			"value":    types.StringPointerValue(record.Value),
+		})
+		diags.Append(d...)
+		values = append(values, obj)
+	}
+	if diags.HasError() {
+		return types.ListNull(types.ObjectType{AttrTypes: dnsRecordAttrTypes}), diags
+	}
+
+	list, d := types.ListValue(types.ObjectType{AttrTypes: dnsRecordAttrTypes}, values)
+	diags.Append(d...)
+	return list, diags
+}
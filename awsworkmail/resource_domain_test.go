@@ -1,9 +1,14 @@
 package awsworkmail
 
 import (
+	"reflect"
 	"testing"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/workmail"
+	wmtypes "github.com/aws/aws-sdk-go-v2/service/workmail/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 func TestDomainResourceBasic(t *testing.T) {
@@ -26,8 +31,45 @@ func TestDomainResourceBasic(t *testing.T) {
 					resource.TestCheckResourceAttr("awsworkmail_domain.test", "domain", "test-domain.example.com"),
 					resource.TestCheckResourceAttrSet("awsworkmail_domain.test", "id"),
 					resource.TestCheckResourceAttrSet("awsworkmail_domain.test", "organization_id"),
+					resource.TestCheckResourceAttrSet("awsworkmail_domain.test", "records.#"),
+					resource.TestCheckTypeSetElemNestedAttrs("awsworkmail_domain.test", "records.*", map[string]string{
+						"type": "MX",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("awsworkmail_domain.test", "records.*", map[string]string{
+						"type": "CNAME",
+					}),
+					resource.TestCheckResourceAttrSet("awsworkmail_domain.test", "ownership_verification_status"),
+					resource.TestCheckResourceAttrSet("awsworkmail_domain.test", "dkim_verification_status"),
 				),
 			},
+			{
+				ResourceName:      "awsworkmail_domain.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs := s.RootModule().Resources["awsworkmail_domain.test"]
+					return rs.Primary.Attributes["organization_id"] + "," + rs.Primary.Attributes["domain"], nil
+				},
+				ImportStateVerifyIgnore: []string{"wait_for_verification", "force_destroy", "timeouts"},
+			},
 		},
 	})
 }
+
+func TestUnverifiedDomainRecords(t *testing.T) {
+	out := &workmail.GetMailDomainOutput{
+		OwnershipVerificationStatus: wmtypes.DnsRecordVerificationStatusVerified,
+		DkimVerificationStatus:      wmtypes.DnsRecordVerificationStatusPending,
+		Records: []wmtypes.DnsRecord{
+			{Type: aws.String("TXT"), Hostname: aws.String("_amazonses.example.com."), Value: aws.String("token")},
+			{Type: aws.String("CNAME"), Hostname: aws.String("abc._domainkey.example.com."), Value: aws.String("abc.dkim.amazonses.com.")},
+			{Type: aws.String("MX"), Hostname: aws.String("example.com."), Value: aws.String("10 inbound-smtp.us-east-1.amazonaws.com.")},
+		},
+	}
+
+	got := unverifiedDomainRecords(out)
+	want := []string{"  CNAME abc._domainkey.example.com. abc.dkim.amazonses.com."}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unverifiedDomainRecords() = %q, want %q", got, want)
+	}
+}
@@ -3,20 +3,33 @@ package awsworkmail
 import (
 	"context"
 	"fmt"
-	"sort"
 	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/workmail"
+	wmtypes "github.com/aws/aws-sdk-go-v2/service/workmail/types"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // awsworkmail_group resource: manages a group in a WorkMail organization
 
+// Ensure implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &groupResource{}
+	_ resource.ResourceWithValidateConfig = &groupResource{}
+	_ resource.ResourceWithModifyPlan     = &groupResource{}
+	_ resource.ResourceWithUpgradeState   = &groupResource{}
+)
+
 type groupResource struct {
 	cfg aws.Config
 }
@@ -26,20 +39,26 @@ func NewGroupResource() resource.Resource {
 }
 
 type groupResourceModel struct {
-	ID             types.String   `tfsdk:"id"`
-	OrganizationID types.String   `tfsdk:"organization_id"`
-	Name           types.String   `tfsdk:"name"`
-	Email          types.String   `tfsdk:"email"`
-	Members        []types.String `tfsdk:"members"`
-	Enabled        types.Bool     `tfsdk:"enabled"`
+	ID             types.String `tfsdk:"id"`
+	OrganizationID types.String `tfsdk:"organization_id"`
+	Name           types.String `tfsdk:"name"`
+	Email          types.String `tfsdk:"email"`
+	Members        types.Set    `tfsdk:"members"`
+	Enabled        types.Bool   `tfsdk:"enabled"`
+
+	KeepPreviousEmail types.Bool `tfsdk:"keep_previous_email_as_alias"`
+	Aliases           types.Set  `tfsdk:"aliases"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *groupResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_group"
 }
 
-func (r *groupResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *groupResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed:            true,
@@ -60,13 +79,100 @@ func (r *groupResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 			"members": schema.SetAttribute{
 				ElementType:         types.StringType,
 				Optional:            true,
-				MarkdownDescription: "Set of user IDs to be members of the group.",
+				MarkdownDescription: "IDs of the users, groups and resources that are members of the group.",
 			},
 			"enabled": schema.BoolAttribute{
 				Optional:            true,
 				Computed:            true,
 				MarkdownDescription: "Whether the group is enabled in WorkMail.",
 			},
+			"aliases": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Email aliases of the group, each on a domain registered with the organization. When set, the aliases are managed authoritatively; when not set, they are only read.",
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"keep_previous_email_as_alias": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Whether to keep the previous primary address as an alias when `email` changes. Defaults to `false`.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+			}),
+		},
+	}
+}
+
+// groupResourceModelV0 is the state of the group resource before version 1,
+// which held members in a list-backed model.
+type groupResourceModelV0 struct {
+	ID             types.String `tfsdk:"id"`
+	OrganizationID types.String `tfsdk:"organization_id"`
+	Name           types.String `tfsdk:"name"`
+	Email          types.String `tfsdk:"email"`
+	Members        types.Set    `tfsdk:"members"`
+	Enabled        types.Bool   `tfsdk:"enabled"`
+}
+
+// UpgradeState migrates version 0 state. Members are de-duplicated and empty
+// IDs written by earlier versions are dropped; the attributes added since are
+// left unset and filled in by the next refresh.
+func (r *groupResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"id":              schema.StringAttribute{Computed: true},
+					"organization_id": schema.StringAttribute{Required: true},
+					"name":            schema.StringAttribute{Required: true},
+					"email":           schema.StringAttribute{Optional: true},
+					"members":         schema.SetAttribute{ElementType: types.StringType, Optional: true},
+					"enabled":         schema.BoolAttribute{Optional: true, Computed: true},
+				},
+			},
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior groupResourceModelV0
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				members := types.SetNull(types.StringType)
+				if !prior.Members.IsNull() && !prior.Members.IsUnknown() {
+					var ids []string
+					resp.Diagnostics.Append(prior.Members.ElementsAs(ctx, &ids, false)...)
+					if resp.Diagnostics.HasError() {
+						return
+					}
+					var diags diag.Diagnostics
+					members, diags = upgradeGroupMembers(ctx, ids)
+					resp.Diagnostics.Append(diags...)
+				}
+
+				upgraded := groupResourceModel{
+					ID:                prior.ID,
+					OrganizationID:    prior.OrganizationID,
+					Name:              prior.Name,
+					Email:             prior.Email,
+					Members:           members,
+					Enabled:           prior.Enabled,
+					KeepPreviousEmail: types.BoolNull(),
+					Aliases:           types.SetNull(types.StringType),
+					Timeouts: timeouts.Value{
+						Object: types.ObjectNull(map[string]attr.Type{
+							"create": types.StringType,
+							"update": types.StringType,
+						}),
+					},
+				}
+				resp.Diagnostics.Append(resp.State.Set(ctx, upgraded)...)
+			},
 		},
 	}
 }
@@ -76,16 +182,99 @@ func (r *groupResource) Configure(_ context.Context, req resource.ConfigureReque
 		return
 	}
 
-	cfg, ok := req.ProviderData.(aws.Config)
+	pd, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected aws.Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.cfg = pd.cfg
+}
+
+func (r *groupResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data groupResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateAliasesConfig(ctx, data.Aliases, data.Email)...)
+	if !data.Aliases.IsNull() && !data.Enabled.IsUnknown() && !data.Enabled.IsNull() && !data.Enabled.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("aliases"),
+			"Aliases require an enabled group",
+			"WorkMail only accepts aliases for enabled groups, so aliases cannot be set when enabled is false.",
 		)
+	}
+}
+
+// ModifyPlan warns when configured aliases use a domain that is not
+// registered with the organization yet.
+func (r *groupResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
 		return
 	}
 
-	r.cfg = cfg
+	var plan, state groupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !req.State.Raw.IsNull() && (!plan.Email.Equal(state.Email) || entityEnabledValue(plan.Enabled) != entityEnabledValue(state.Enabled)) {
+		resp.Diagnostics.Append(planAliasesAfterAddressChange(ctx, req.Config, &resp.Plan)...)
+	}
+
+	client := workmail.NewFromConfig(r.cfg)
+	if !plan.Aliases.Equal(state.Aliases) {
+		resp.Diagnostics.Append(warnUnregisteredAliasDomains(ctx, client, plan.OrganizationID, plan.Aliases)...)
+	}
+
+	// A new group cannot be a member of anything yet, so only groups that
+	// already exist can end up in a membership cycle. Groups in the same
+	// configuration that list each other's IDs never get here: Terraform
+	// rejects them as a dependency cycle. Other groups are checked against
+	// their current members, since this plan may still remove the edge that
+	// closes the loop; a cycle is therefore only a warning, and WorkMail
+	// rejects a real one at apply.
+	if req.State.Raw.IsNull() || plan.Members.IsUnknown() || plan.Members.IsNull() || plan.Members.Equal(state.Members) {
+		return
+	}
+	var members []types.String
+	resp.Diagnostics.Append(plan.Members.ElementsAs(ctx, &members, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ids := make([]string, 0, len(members))
+	for _, m := range members {
+		if !m.IsUnknown() {
+			ids = append(ids, m.ValueString())
+		}
+	}
+	cycle, err := findMembershipCycle(ctx, client, state.OrganizationID.ValueString(), state.ID.ValueString(), ids)
+	if err != nil {
+		// The apply reports API errors; do not block the plan on them.
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("members"),
+			"Unable to check group membership cycles",
+			"Nested group memberships could not be read, so membership cycles were not checked: "+err.Error(),
+		)
+		return
+	}
+	if cycle != nil {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("members"),
+			"Group membership cycle",
+			"With the current members of the other groups, adding these members would make group "+state.ID.ValueString()+" a member of itself: "+strings.Join(cycle, " -> ")+
+				". The apply fails unless another change in this plan removes one of these memberships first.",
+		)
+	}
 }
 
 func (r *groupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -110,9 +299,10 @@ func (r *groupResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	// Enable group if enabled=true (default: true)
 	enabled := true
-	if !data.Enabled.IsNull() {
+	if !data.Enabled.IsNull() && !data.Enabled.IsUnknown() {
 		enabled = data.Enabled.ValueBool()
 	}
+	data.Enabled = types.BoolValue(enabled)
 	if enabled {
 		_, err := client.RegisterToWorkMail(ctx, &workmail.RegisterToWorkMailInput{
 			OrganizationId: aws.String(data.OrganizationID.ValueString()),
@@ -126,34 +316,36 @@ func (r *groupResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 
 	// Add members if provided
-	for _, member := range data.Members {
-		// Wait for user to be ENABLED
-		userEnabled := false
-		for i := 0; i < 30; i++ { // up to ~5 minutes
-			desc, err := client.DescribeUser(ctx, &workmail.DescribeUserInput{
-				OrganizationId: aws.String(data.OrganizationID.ValueString()),
-				UserId:         aws.String(member.ValueString()),
-			})
-			if err == nil && string(desc.State) == "ENABLED" {
-				userEnabled = true
-				break
-			}
-			time.Sleep(10 * time.Second)
-		}
-		if !userEnabled {
-			resp.Diagnostics.AddWarning("User not enabled", "User "+member.ValueString()+" was not enabled after 5 minutes. Group membership may fail.")
-		}
-		_, err := client.AssociateMemberToGroup(ctx, &workmail.AssociateMemberToGroupInput{
-			OrganizationId: aws.String(data.OrganizationID.ValueString()),
-			GroupId:        out.GroupId,
-			MemberId:       aws.String(member.ValueString()),
-		})
-		if err != nil {
-			resp.Diagnostics.AddError("Error adding member to group", err.Error())
-			return
+	var members []string
+	resp.Diagnostics.Append(data.Members.ElementsAs(ctx, &members, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultMemberTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	add, _ := diffMembers(nil, members)
+	resp.Diagnostics.Append(applyGroupMemberChanges(ctx, client, &data, createTimeout, add, nil)...)
+	if resp.Diagnostics.HasError() {
+		// The group exists, so save it rather than leave it outside
+		// Terraform. A create that fails marks the resource tainted, so the
+		// next apply replaces the group.
+		if data.Aliases.IsUnknown() {
+			data.Aliases = types.SetNull(types.StringType)
 		}
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
 	}
 
+	aliases, diags := setEntityAliases(ctx, client, req.Config, data.OrganizationID.ValueString(), data.ID.ValueString(), data.Email.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Aliases = aliases
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -172,9 +364,26 @@ func (r *groupResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	}
 	out, err := client.DescribeGroup(ctx, input)
 	if err != nil {
+		// If the group was deleted outside Terraform, remove it from state
+		if isEntityNotFound(err) {
+			resp.Diagnostics.AddWarning(
+				"WorkMail group not found",
+				"Group "+data.ID.ValueString()+" no longer exists in organization "+data.OrganizationID.ValueString()+" and has been removed from state.",
+			)
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError("Error reading WorkMail group", err.Error())
 		return
 	}
+	if out.State == wmtypes.EntityStateDeleted {
+		resp.Diagnostics.AddWarning(
+			"WorkMail group deleted",
+			"Group "+data.ID.ValueString()+" is in the DELETED state in organization "+data.OrganizationID.ValueString()+" and has been removed from state.",
+		)
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if out != nil && out.GroupId != nil {
 		data.ID = types.StringValue(*out.GroupId)
 	}
@@ -189,44 +398,37 @@ func (r *groupResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	}
 
 	// Read group members
-	members := []types.String{}
-	listInput := &workmail.ListGroupMembersInput{
-		OrganizationId: aws.String(data.OrganizationID.ValueString()),
-		GroupId:        aws.String(data.ID.ValueString()),
+	members, err := listGroupMembers(ctx, client, data.OrganizationID.ValueString(), data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading WorkMail group members", err.Error())
+		return
 	}
-	listOut, err := client.ListGroupMembers(ctx, listInput)
-	if err == nil {
-		unique := map[string]struct{}{}
-		for _, m := range listOut.Members {
-			if m.Id != nil {
-				unique[*m.Id] = struct{}{}
-			}
-		}
-		// Sort members to ensure consistent ordering in state
-		sorted := make([]string, 0, len(unique))
-		for id := range unique {
-			sorted = append(sorted, id)
-		}
-		sort.Strings(sorted)
-		membersSorted := make([]types.String, len(sorted))
-		for i, v := range sorted {
-			membersSorted[i] = types.StringValue(v)
-		}
-		data.Members = membersSorted
-	} else {
-		data.Members = members
+	ids := memberIDs(members)
+	// Keep members unset when it is not configured and the group is empty.
+	if len(ids) > 0 || !data.Members.IsNull() {
+		memberSet, diags := types.SetValueFrom(ctx, types.StringType, ids)
+		resp.Diagnostics.Append(diags...)
+		data.Members = memberSet
 	}
 
+	aliases, err := readEntityAliases(ctx, client, data.OrganizationID.ValueString(), data.ID.ValueString(), data.Email.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading WorkMail group aliases", err.Error())
+		return
+	}
+	aliasSet, diags := types.SetValueFrom(ctx, types.StringType, aliases)
+	resp.Diagnostics.Append(diags...)
+	data.Aliases = aliasSet
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *groupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data groupResourceModel
+	var data, stateData groupResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateData)...)
 	// Fallback: if id did not come from the plan, get it from the state
 	if data.ID.IsNull() || data.ID.ValueString() == "" {
-		var stateData groupResourceModel
-		resp.Diagnostics.Append(req.State.Get(ctx, &stateData)...)
 		data.ID = stateData.ID
 	}
 	if resp.Diagnostics.HasError() {
@@ -236,14 +438,24 @@ func (r *groupResource) Update(ctx context.Context, req resource.UpdateRequest,
 	client := workmail.NewFromConfig(r.cfg)
 	// Enable/disable group if needed
 	enabled := true
-	if !data.Enabled.IsNull() {
+	if !data.Enabled.IsNull() && !data.Enabled.IsUnknown() {
 		enabled = data.Enabled.ValueBool()
 	}
+	data.Enabled = types.BoolValue(enabled)
 	if data.ID.IsNull() || data.ID.ValueString() == "" {
 		resp.Diagnostics.AddError("WorkMail group ID is missing", "The group id field is empty. This can occur after manual import. Please check if the resource was imported correctly and the state is healthy.")
 		return
 	}
-	if enabled {
+	wasEnabled := stateData.Enabled.ValueBool()
+	if primaryEmailChanged(data.Email, stateData.Email, wasEnabled, enabled) {
+		// Registering an enabled group again fails, so change the address in place.
+		err := updatePrimaryEmail(ctx, client, data.OrganizationID.ValueString(), data.ID.ValueString(),
+			stateData.Email.ValueString(), data.Email.ValueString(), data.KeepPreviousEmail.ValueBool())
+		if err != nil {
+			resp.Diagnostics.AddError("Error updating WorkMail group primary email address", err.Error())
+			return
+		}
+	} else if enabled && !wasEnabled {
 		_, err := client.RegisterToWorkMail(ctx, &workmail.RegisterToWorkMailInput{
 			OrganizationId: aws.String(data.OrganizationID.ValueString()),
 			EntityId:       aws.String(data.ID.ValueString()),
@@ -253,7 +465,7 @@ func (r *groupResource) Update(ctx context.Context, req resource.UpdateRequest,
 			resp.Diagnostics.AddError("Error enabling WorkMail group", err.Error())
 			return
 		}
-	} else {
+	} else if !enabled && wasEnabled {
 		_, err := client.DeregisterFromWorkMail(ctx, &workmail.DeregisterFromWorkMailInput{
 			OrganizationId: aws.String(data.OrganizationID.ValueString()),
 			EntityId:       aws.String(data.ID.ValueString()),
@@ -265,71 +477,66 @@ func (r *groupResource) Update(ctx context.Context, req resource.UpdateRequest,
 	}
 
 	// Update group name is not supported by AWS, so only manage members
-	// Get current members
-	currentMembers := map[string]struct{}{}
-	listOut, err := client.ListGroupMembers(ctx, &workmail.ListGroupMembersInput{
-		OrganizationId: aws.String(data.OrganizationID.ValueString()),
-		GroupId:        aws.String(data.ID.ValueString()),
-	})
-	if err == nil {
-		for _, m := range listOut.Members {
-			if m.Id != nil {
-				currentMembers[*m.Id] = struct{}{}
-			}
-		}
+	current, err := listGroupMembers(ctx, client, data.OrganizationID.ValueString(), data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading WorkMail group members", err.Error())
+		return
 	}
-	// Build desired members set
-	desiredMembers := map[string]struct{}{}
-	for _, m := range data.Members {
-		desiredMembers[m.ValueString()] = struct{}{}
-	}
-	// Add new members
-	for m := range desiredMembers {
-		if _, exists := currentMembers[m]; !exists {
-			// Wait for user to be ENABLED
-			userEnabled := false
-			for i := 0; i < 30; i++ {
-				desc, err := client.DescribeUser(ctx, &workmail.DescribeUserInput{
-					OrganizationId: aws.String(data.OrganizationID.ValueString()),
-					UserId:         aws.String(m),
-				})
-				if err == nil && string(desc.State) == "ENABLED" {
-					userEnabled = true
-					break
-				}
-				time.Sleep(10 * time.Second)
-			}
-			if !userEnabled {
-				resp.Diagnostics.AddWarning("User not enabled", "User "+m+" was not enabled after 5 minutes. Group membership may fail.")
-			}
-			_, err := client.AssociateMemberToGroup(ctx, &workmail.AssociateMemberToGroupInput{
-				OrganizationId: aws.String(data.OrganizationID.ValueString()),
-				GroupId:        aws.String(data.ID.ValueString()),
-				MemberId:       aws.String(m),
-			})
-			if err != nil {
-				resp.Diagnostics.AddError("Error adding member to group", err.Error())
-				return
-			}
-		}
+	var members []string
+	resp.Diagnostics.Append(data.Members.ElementsAs(ctx, &members, false)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	// Remove old members
-	for m := range currentMembers {
-		if _, exists := desiredMembers[m]; !exists {
-			_, err := client.DisassociateMemberFromGroup(ctx, &workmail.DisassociateMemberFromGroupInput{
-				OrganizationId: aws.String(data.OrganizationID.ValueString()),
-				GroupId:        aws.String(data.ID.ValueString()),
-				MemberId:       aws.String(m),
-			})
-			if err != nil {
-				resp.Diagnostics.AddError("Error removing member from group", err.Error())
-				return
-			}
-		}
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultMemberTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	add, remove := diffMembers(memberIDs(current), members)
+	resp.Diagnostics.Append(applyGroupMemberChanges(ctx, client, &data, updateTimeout, add, remove)...)
+	if resp.Diagnostics.HasError() {
+		data.Aliases = stateData.Aliases
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+	aliases, diags := setEntityAliases(ctx, client, req.Config, data.OrganizationID.ValueString(), data.ID.ValueString(), data.Email.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	data.Aliases = aliases
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// applyGroupMemberChanges adds and removes members within timeout. On failure
+// the members are read back into data, so that state records the changes
+// that were applied and the next plan retries the rest.
+func applyGroupMemberChanges(ctx context.Context, client *workmail.Client, data *groupResourceModel, timeout time.Duration, add, remove []string) diag.Diagnostics {
+	if len(add) == 0 && len(remove) == 0 {
+		return nil
+	}
+	reconcileCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	diags := reconcileGroupMembers(reconcileCtx, client, data.OrganizationID.ValueString(), data.ID.ValueString(), add, remove)
+	if !diags.HasError() {
+		return diags
+	}
+
+	current, err := listGroupMembers(ctx, client, data.OrganizationID.ValueString(), data.ID.ValueString())
+	if err != nil {
+		diags.AddError("Error reading WorkMail group members", err.Error())
+		return diags
+	}
+	ids := memberIDs(current)
+	if data.Members.IsNull() && len(ids) == 0 {
+		return diags
+	}
+	members, d := types.SetValueFrom(ctx, types.StringType, ids)
+	diags.Append(d...)
+	data.Members = members
+	return diags
+}
+
 func (r *groupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var data groupResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -352,10 +559,16 @@ func (r *groupResource) ImportState(ctx context.Context, req resource.ImportStat
 	if len(parts) != 2 {
 		resp.Diagnostics.AddError(
 			"Invalid import ID format",
-			"Expected import ID format: <organization_id>,<group_id>",
+			"Expected import ID format: <organization_id>,<group_id>, <organization_id>,<name> or <organization_id>,<email>",
 		)
 		return
 	}
+
+	id, err := resolveImportEntityID(ctx, workmail.NewFromConfig(r.cfg), parts[0], parts[1], wmtypes.EntityTypeGroup)
+	if err != nil {
+		resp.Diagnostics.AddError("Error resolving WorkMail group to import", err.Error())
+		return
+	}
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("organization_id"), parts[0])...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
 }
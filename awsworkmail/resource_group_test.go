@@ -0,0 +1,205 @@
+package awsworkmail
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	wmtypes "github.com/aws/aws-sdk-go-v2/service/workmail/types"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccGroup_members(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGroupMembersConfig(`[awsworkmail_user.a.id, awsworkmail_user.b.id]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awsworkmail_group.test", "members.#", "2"),
+					resource.TestCheckTypeSetElemAttrPair("awsworkmail_group.test", "members.*", "awsworkmail_user.a", "id"),
+				),
+			},
+			{
+				Config: testAccGroupMembersConfig(`[awsworkmail_user.b.id]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awsworkmail_group.test", "members.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair("awsworkmail_group.test", "members.*", "awsworkmail_user.b", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccGroup_nested(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGroupNestedConfig(`null`, `[awsworkmail_group.child.id]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awsworkmail_group.parent", "members.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair("awsworkmail_group.parent", "members.*", "awsworkmail_group.child", "id"),
+				),
+			},
+			{
+				// Inverting the nesting is not a cycle: the parent drops the
+				// child before the child, which depends on it, adds the parent.
+				Config: testAccGroupNestedConfig(`[awsworkmail_group.parent.id]`, `null`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("awsworkmail_group.parent", "members.#"),
+					resource.TestCheckResourceAttr("awsworkmail_group.child", "members.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair("awsworkmail_group.child", "members.*", "awsworkmail_group.parent", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccGroupNestedConfig(childMembers, parentMembers string) string {
+	return fmt.Sprintf(`
+resource "awsworkmail_organization" "test" {
+  alias = "tfacc-group-nested"
+}
+
+resource "awsworkmail_group" "child" {
+  organization_id = awsworkmail_organization.test.id
+  name            = "tfacc-child"
+  email           = "tfacc-child@${awsworkmail_organization.test.alias}.awsapps.com"
+  members         = %s
+}
+
+resource "awsworkmail_group" "parent" {
+  organization_id = awsworkmail_organization.test.id
+  name            = "tfacc-parent"
+  email           = "tfacc-parent@${awsworkmail_organization.test.alias}.awsapps.com"
+  members         = %s
+}
+`, childMembers, parentMembers)
+}
+
+func testAccGroupMembersConfig(members string) string {
+	return fmt.Sprintf(`
+resource "awsworkmail_organization" "test" {
+  alias = "tfacc-group-members"
+}
+
+resource "awsworkmail_user" "a" {
+  organization_id = awsworkmail_organization.test.id
+  name            = "tfacc.member.a"
+  display_name    = "Tfacc Member A"
+  password        = "ChangeMe123!"
+}
+
+resource "awsworkmail_user" "b" {
+  organization_id = awsworkmail_organization.test.id
+  name            = "tfacc.member.b"
+  display_name    = "Tfacc Member B"
+  password        = "ChangeMe123!"
+}
+
+resource "awsworkmail_group" "test" {
+  organization_id = awsworkmail_organization.test.id
+  name            = "tfacc-group"
+  email           = "tfacc-group@${awsworkmail_organization.test.alias}.awsapps.com"
+  members         = %s
+}
+`, members)
+}
+
+func TestGroupRead_removesMissingGroup(t *testing.T) {
+	stub := newStubWorkMail(nil)
+	stub.groups["g-deleted"] = wmtypes.EntityStateDeleted
+
+	for id, summary := range map[string]string{
+		"g-gone":    "WorkMail group not found",
+		"g-deleted": "WorkMail group deleted",
+	} {
+		resp := readTestResource(t, &groupResource{cfg: stub.config(t)}, id)
+		if !resp.State.Raw.IsNull() {
+			t.Errorf("%s: group was not removed from state", id)
+		}
+		if warnings := diagSummaries(resp.Diagnostics, diag.SeverityWarning); len(warnings) != 1 || !strings.HasPrefix(warnings[0], summary) {
+			t.Errorf("%s: warnings = %q, want one %q", id, warnings, summary)
+		}
+		if resp.Diagnostics.HasError() {
+			t.Errorf("%s: unexpected errors: %v", id, resp.Diagnostics)
+		}
+	}
+}
+
+// planGroupMembers runs ModifyPlan on a group with the given current and
+// planned members, and returns the response.
+func planGroupMembers(t *testing.T, r *groupResource, id string, current, planned []string) *fwresource.ModifyPlanResponse {
+	t.Helper()
+	ctx := context.Background()
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	build := func(members []string) (tfsdk.Plan, diag.Diagnostics) {
+		plan := tfsdk.Plan{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		}
+		diags := plan.SetAttribute(ctx, path.Root("id"), id)
+		diags.Append(plan.SetAttribute(ctx, path.Root("organization_id"), "m-1")...)
+		if members != nil {
+			diags.Append(plan.SetAttribute(ctx, path.Root("members"), members)...)
+		}
+		return plan, diags
+	}
+	state, diags := build(current)
+	plan, planDiags := build(planned)
+	diags.Append(planDiags...)
+	if diags.HasError() {
+		t.Fatalf("building plan: %v", diags)
+	}
+
+	req := fwresource.ModifyPlanRequest{
+		State:  tfsdk.State{Schema: state.Schema, Raw: state.Raw},
+		Plan:   plan,
+		Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw},
+	}
+	resp := &fwresource.ModifyPlanResponse{Plan: plan}
+	r.ModifyPlan(ctx, req, resp)
+	return resp
+}
+
+func TestGroupModifyPlan_membershipCycle(t *testing.T) {
+	stub := newStubWorkMail(nil)
+	stub.groups["g-parent"] = wmtypes.EntityStateEnabled
+	stub.groups["g-child"] = wmtypes.EntityStateEnabled
+	r := &groupResource{cfg: stub.config(t)}
+
+	// The parent keeps the child, and the child adds the parent.
+	stub.nested["g-parent"] = []string{"g-child"}
+	resp := planGroupMembers(t, r, "g-child", nil, []string{"g-parent"})
+	warnings := diagSummaries(resp.Diagnostics, diag.SeverityWarning)
+	if len(warnings) != 1 || !strings.HasPrefix(warnings[0], "Group membership cycle") || !strings.Contains(warnings[0], "g-child -> g-parent -> g-child") {
+		t.Errorf("warnings = %q, want a membership cycle through g-parent", warnings)
+	}
+	if resp.Diagnostics.HasError() {
+		t.Errorf("a cycle must not fail the plan: %v", resp.Diagnostics)
+	}
+
+	// Without the parent's membership there is nothing to report.
+	stub.nested["g-parent"] = nil
+	resp = planGroupMembers(t, r, "g-child", nil, []string{"g-parent"})
+	if len(resp.Diagnostics) != 0 {
+		t.Errorf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	// Adding the group to itself is reported without reading other groups.
+	resp = planGroupMembers(t, r, "g-child", nil, []string{"g-child"})
+	if warnings := diagSummaries(resp.Diagnostics, diag.SeverityWarning); len(warnings) != 1 || !strings.Contains(warnings[0], "g-child -> g-child") {
+		t.Errorf("warnings = %q, want a self-membership cycle", warnings)
+	}
+}
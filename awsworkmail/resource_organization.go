@@ -6,14 +6,21 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/workmail"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
+// Ensure organizationResource computes tags_all during planning.
+var _ resource.ResourceWithModifyPlan = &organizationResource{}
+
 type organizationResource struct {
-	cfg aws.Config
+	cfg         aws.Config
+	defaultTags map[string]string
 }
 
 // NewOrganizationResource returns a new WorkMail organization resource.
@@ -22,8 +29,11 @@ func NewOrganizationResource() resource.Resource {
 }
 
 type organizationResourceModel struct {
-	ID    types.String `tfsdk:"id"`
-	Alias types.String `tfsdk:"alias"`
+	ID      types.String `tfsdk:"id"`
+	Alias   types.String `tfsdk:"alias"`
+	ARN     types.String `tfsdk:"arn"`
+	Tags    types.Map    `tfsdk:"tags"`
+	TagsAll types.Map    `tfsdk:"tags_all"`
 }
 
 // Metadata sets the resource type name.
@@ -37,13 +47,14 @@ func (r *organizationResource) Configure(ctx context.Context, req resource.Confi
 		return
 	}
 
-	cfg, ok := req.ProviderData.(aws.Config)
+	pd, ok := req.ProviderData.(*providerData)
 	if !ok {
-		resp.Diagnostics.AddError("Unexpected Resource Configure Type", "Expected aws.Config, got something else. Please report this issue to the provider developers.")
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", "Expected *providerData, got something else. Please report this issue to the provider developers.")
 		return
 	}
 
-	r.cfg = cfg
+	r.cfg = pd.cfg
+	r.defaultTags = pd.defaultTags
 }
 
 // Schema defines the schema for the resource.
@@ -58,6 +69,23 @@ func (r *organizationResource) Schema(_ context.Context, _ resource.SchemaReques
 				Required:            true,
 				MarkdownDescription: "Alias for the WorkMail organization",
 			},
+			"arn": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "ARN of the WorkMail organization",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"tags": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Tags to assign to the WorkMail organization. Tags with the same key as a provider `default_tags` entry override it.",
+			},
+			"tags_all": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "All tags assigned to the WorkMail organization, including those inherited from the provider `default_tags`.",
+			},
 		},
 	}
 }
@@ -106,6 +134,28 @@ active:
 	data.ID = types.StringValue(orgID)
 	data.Alias = types.StringValue(alias)
 
+	descOut, err := client.DescribeOrganization(ctx, &workmail.DescribeOrganizationInput{
+		OrganizationId: &orgID,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Error describing WorkMail organization", err.Error())
+		return
+	}
+	data.ARN = types.StringPointerValue(descOut.ARN)
+
+	tags, diags := r.configuredTags(ctx, data.Tags)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tagsAll := mergeTags(r.defaultTags, tags)
+	if err := updateTags(ctx, client, data.ARN.ValueString(), nil, tagsAll); err != nil {
+		resp.Diagnostics.AddError("Error tagging WorkMail organization", err.Error())
+		return
+	}
+	data.TagsAll, diags = types.MapValueFrom(ctx, types.StringType, tagsAll)
+	resp.Diagnostics.Append(diags...)
+
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 }
@@ -122,17 +172,21 @@ func (r *organizationResource) Read(ctx context.Context, req resource.ReadReques
 	client := workmail.NewFromConfig(r.cfg)
 
 	orgID := data.ID.ValueString()
-	listOut, err := client.ListOrganizations(ctx, &workmail.ListOrganizationsInput{})
+	orgs, err := listOrganizations(ctx, client)
 	if err != nil {
 		resp.Diagnostics.AddError("Error listing WorkMail organizations", err.Error())
 		return
 	}
-	for _, org := range listOut.OrganizationSummaries {
+	for _, org := range orgs {
 		if org.OrganizationId != nil && *org.OrganizationId == orgID {
 			data.ID = types.StringValue(*org.OrganizationId)
 			if org.Alias != nil {
 				data.Alias = types.StringValue(*org.Alias)
 			}
+			resp.Diagnostics.Append(r.readTags(ctx, client, &data)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
 			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 			return
 		}
@@ -141,16 +195,119 @@ func (r *organizationResource) Read(ctx context.Context, req resource.ReadReques
 	resp.State.RemoveResource(ctx)
 }
 
-// Update is a no-op because WorkMail does not support updating alias.
+// Update reconciles tags. WorkMail does not support updating the alias.
 func (r *organizationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data organizationResourceModel
+	var data, state organizationResourceModel
 	diags := req.Plan.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := workmail.NewFromConfig(r.cfg)
+
+	oldTagsAll, diags := r.configuredTags(ctx, state.TagsAll)
+	resp.Diagnostics.Append(diags...)
+	tags, diags := r.configuredTags(ctx, data.Tags)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tagsAll := mergeTags(r.defaultTags, tags)
+	if err := updateTags(ctx, client, state.ARN.ValueString(), oldTagsAll, tagsAll); err != nil {
+		resp.Diagnostics.AddError("Error updating WorkMail organization tags", err.Error())
+		return
+	}
+	data.ID = state.ID
+	data.ARN = state.ARN
+	data.TagsAll, diags = types.MapValueFrom(ctx, types.StringType, tagsAll)
+	resp.Diagnostics.Append(diags...)
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 }
 
+// ModifyPlan computes tags_all from the resource tags and the provider default tags.
+func (r *organizationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan organizationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Tags.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("tags_all"), types.MapUnknown(types.StringType))...)
+		return
+	}
+
+	tags, diags := r.configuredTags(ctx, plan.Tags)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tagsAll, diags := types.MapValueFrom(ctx, types.StringType, mergeTags(r.defaultTags, tags))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("tags_all"), tagsAll)...)
+}
+
+// configuredTags converts a tags map attribute into a Go map, treating null as empty.
+func (r *organizationResource) configuredTags(ctx context.Context, m types.Map) (map[string]string, diag.Diagnostics) {
+	tags := map[string]string{}
+	if m.IsNull() || m.IsUnknown() {
+		return tags, nil
+	}
+	diags := m.ElementsAs(ctx, &tags, false)
+	return tags, diags
+}
+
+// readTags refreshes arn, tags and tags_all from AWS so that tags changed
+// outside Terraform show up as drift.
+func (r *organizationResource) readTags(ctx context.Context, client *workmail.Client, data *organizationResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	orgID := data.ID.ValueString()
+	descOut, err := client.DescribeOrganization(ctx, &workmail.DescribeOrganizationInput{
+		OrganizationId: &orgID,
+	})
+	if err != nil {
+		diags.AddError("Error describing WorkMail organization", err.Error())
+		return diags
+	}
+	data.ARN = types.StringPointerValue(descOut.ARN)
+
+	remote, err := listTags(ctx, client, data.ARN.ValueString())
+	if err != nil {
+		diags.AddError("Error listing WorkMail organization tags", err.Error())
+		return diags
+	}
+
+	configured, d := r.configuredTags(ctx, data.Tags)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+
+	tags := resourceTags(remote, configured, r.defaultTags)
+	if len(tags) == 0 && data.Tags.IsNull() {
+		data.Tags = types.MapNull(types.StringType)
+	} else {
+		data.Tags, d = types.MapValueFrom(ctx, types.StringType, tags)
+		diags.Append(d...)
+	}
+	data.TagsAll, d = types.MapValueFrom(ctx, types.StringType, remote)
+	diags.Append(d...)
+
+	return diags
+}
+
 // Delete deletes the WorkMail organization.
 func (r *organizationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var data organizationResourceModel
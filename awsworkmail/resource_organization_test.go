@@ -29,6 +29,37 @@ func TestAccOrganization_Basic(t *testing.T) {
 	})
 }
 
+func TestAccOrganization_Tags(t *testing.T) {
+	orgAlias := os.Getenv("TF_AWSWORKMAIL_ORG_ALIAS")
+	if orgAlias == "" {
+		orgAlias = "tfacc-org-tags"
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOrganizationConfigTags(orgAlias, "platform"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("awsworkmail_organization.test", "arn"),
+					resource.TestCheckResourceAttr("awsworkmail_organization.test", "tags.%", "1"),
+					resource.TestCheckResourceAttr("awsworkmail_organization.test", "tags.Team", "platform"),
+					resource.TestCheckResourceAttr("awsworkmail_organization.test", "tags_all.%", "2"),
+					resource.TestCheckResourceAttr("awsworkmail_organization.test", "tags_all.ManagedBy", "terraform"),
+				),
+			},
+			{
+				Config: testAccOrganizationConfigTags(orgAlias, "messaging"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awsworkmail_organization.test", "tags.Team", "messaging"),
+					resource.TestCheckResourceAttr("awsworkmail_organization.test", "tags_all.Team", "messaging"),
+				),
+			},
+		},
+	})
+}
+
 func testAccOrganizationConfig(alias string) string {
 	return fmt.Sprintf(`
 resource "awsworkmail_organization" "test" {
@@ -36,3 +67,23 @@ resource "awsworkmail_organization" "test" {
 }
 `, alias)
 }
+
+func testAccOrganizationConfigTags(alias, team string) string {
+	return fmt.Sprintf(`
+provider "awsworkmail" {
+  default_tags {
+    tags = {
+      ManagedBy = "terraform"
+    }
+  }
+}
+
+resource "awsworkmail_organization" "test" {
+  alias = "%s"
+
+  tags = {
+    Team = "%s"
+  }
+}
+`, alias, team)
+}
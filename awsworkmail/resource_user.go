@@ -4,17 +4,62 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/workmail"
+	wmtypes "github.com/aws/aws-sdk-go-v2/service/workmail/types"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/float64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // awsworkmail_user resource: manages a user in a WorkMail organization
 
+// Ensure implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &userResource{}
+	_ resource.ResourceWithValidateConfig = &userResource{}
+	_ resource.ResourceWithModifyPlan     = &userResource{}
+)
+
+const (
+	// userStateTimeout bounds the wait for a user to become enabled or disabled.
+	userStateTimeout = 5 * time.Minute
+	// userStatePollInterval is the delay between DescribeUser polls.
+	userStatePollInterval = 5 * time.Second
+)
+
+// Mailbox quota range accepted by UpdateMailboxQuota, in MB.
+const (
+	minMailboxQuotaMB = 1
+	maxMailboxQuotaMB = 51200
+)
+
+// Values of the on_destroy attribute.
+const (
+	userOnDestroyDelete              = "delete"
+	userOnDestroyDisable             = "disable"
+	userOnDestroyDeregisterAndDelete = "deregister_and_delete"
+)
+
+// userRoles are the roles that can be assigned to a user. SYSTEM_USER is
+// reserved for directory administrators and cannot be set through the API.
+var userRoles = []wmtypes.UserRole{
+	wmtypes.UserRoleUser,
+	wmtypes.UserRoleResource,
+	wmtypes.UserRoleRemoteUser,
+}
+
 type userResource struct {
 	cfg aws.Config
 }
@@ -24,15 +69,41 @@ func NewUserResource() resource.Resource {
 }
 
 type userResourceModel struct {
-	ID             types.String `tfsdk:"id"`
-	OrganizationID types.String `tfsdk:"organization_id"`
-	Name           types.String `tfsdk:"name"`
-	DisplayName    types.String `tfsdk:"display_name"`
-	FirstName      types.String `tfsdk:"first_name"`
-	LastName       types.String `tfsdk:"last_name"`
-	Password       types.String `tfsdk:"password"`
-	Email          types.String `tfsdk:"email"`
-	Enabled        types.Bool   `tfsdk:"enabled"`
+	ID                types.String `tfsdk:"id"`
+	OrganizationID    types.String `tfsdk:"organization_id"`
+	Name              types.String `tfsdk:"name"`
+	DisplayName       types.String `tfsdk:"display_name"`
+	FirstName         types.String `tfsdk:"first_name"`
+	LastName          types.String `tfsdk:"last_name"`
+	Password          types.String `tfsdk:"password"`
+	PasswordWO        types.String `tfsdk:"password_wo"`
+	PasswordWOVersion types.Int64  `tfsdk:"password_wo_version"`
+	Email             types.String `tfsdk:"email"`
+	Enabled           types.Bool   `tfsdk:"enabled"`
+	KeepPreviousEmail types.Bool   `tfsdk:"keep_previous_email_as_alias"`
+	OnDestroy         types.String `tfsdk:"on_destroy"`
+	Aliases           types.Set    `tfsdk:"aliases"`
+
+	EnabledDate              types.String  `tfsdk:"enabled_date"`
+	DisabledDate             types.String  `tfsdk:"disabled_date"`
+	MailboxProvisionedDate   types.String  `tfsdk:"mailbox_provisioned_date"`
+	MailboxDeprovisionedDate types.String  `tfsdk:"mailbox_deprovisioned_date"`
+	MailboxQuotaMB           types.Int64   `tfsdk:"mailbox_quota_mb"`
+	MailboxSizeMB            types.Float64 `tfsdk:"mailbox_size_mb"`
+	Role                     types.String  `tfsdk:"role"`
+
+	Initials                    types.String `tfsdk:"initials"`
+	Telephone                   types.String `tfsdk:"telephone"`
+	Street                      types.String `tfsdk:"street"`
+	City                        types.String `tfsdk:"city"`
+	ZipCode                     types.String `tfsdk:"zip_code"`
+	Country                     types.String `tfsdk:"country"`
+	Office                      types.String `tfsdk:"office"`
+	Company                     types.String `tfsdk:"company"`
+	Department                  types.String `tfsdk:"department"`
+	JobTitle                    types.String `tfsdk:"job_title"`
+	HiddenFromGlobalAddressList types.Bool   `tfsdk:"hidden_from_global_address_list"`
+	IdentityProviderUserID      types.String `tfsdk:"identity_provider_user_id"`
 }
 
 func (r *userResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -59,19 +130,58 @@ func (r *userResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				MarkdownDescription: "Display name for the user",
 			},
 			"password": schema.StringAttribute{
-				Required:            true,
+				Optional:            true,
 				Sensitive:           true,
-				MarkdownDescription: "Password for the user (must meet AWS WorkMail requirements)",
+				MarkdownDescription: "Password for the user (must meet AWS WorkMail requirements). Stored in state; prefer `password_wo`. One of `password` or `password_wo` is required unless `role` is `REMOTE_USER` or `identity_provider_user_id` is set.",
+			},
+			"password_wo": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+				MarkdownDescription: "Write-only password for the user, never stored in state (requires Terraform 1.11 or later). Only sent to WorkMail on create and when `password_wo_version` changes.",
+			},
+			"password_wo_version": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Version of `password_wo`. Change it to reset the password to the current `password_wo` value.",
+			},
+			"role": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Role of the user: `USER`, `RESOURCE` or `REMOTE_USER`. Defaults to `USER`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"email": schema.StringAttribute{
 				Optional:            true,
-				MarkdownDescription: "Primary email address for the user (optional, can be set after creation)",
+				Computed:            true,
+				MarkdownDescription: "Primary email address for the user. Defaults to `<name>@<default mail domain>` when the user is enabled.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"enabled": schema.BoolAttribute{
 				Optional:            true,
 				Computed:            true,
 				MarkdownDescription: "Whether the user is enabled in WorkMail.",
 			},
+			"keep_previous_email_as_alias": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Whether to keep the previous primary address as an alias when `email` changes. Defaults to `false`.",
+			},
+			"aliases": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Email aliases of the user, each on a domain registered with the organization. When set, the aliases are managed authoritatively; when not set, they are only read.",
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"on_destroy": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "What happens to the user when the resource is destroyed: `deregister_and_delete` (default) disables the user and then deletes it, `delete` only deletes it, and `disable` disables the user and keeps it and its mailbox in WorkMail.",
+			},
 			"first_name": schema.StringAttribute{
 				Optional:            true,
 				MarkdownDescription: "First name of the user (optional)",
@@ -80,6 +190,101 @@ func (r *userResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				Optional:            true,
 				MarkdownDescription: "Last name of the user (optional)",
 			},
+			"initials": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Initials of the user (optional)",
+			},
+			"telephone": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Telephone number of the user (optional)",
+			},
+			"street": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Street address of the user (optional)",
+			},
+			"city": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "City where the user is located (optional)",
+			},
+			"zip_code": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Zip code of the user (optional)",
+			},
+			"country": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Country where the user is located (optional)",
+			},
+			"office": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Office where the user is located (optional)",
+			},
+			"company": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Company of the user (optional)",
+			},
+			"department": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Department of the user (optional)",
+			},
+			"job_title": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Job title of the user (optional)",
+			},
+			"hidden_from_global_address_list": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Whether the user is hidden from the global address list.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"identity_provider_user_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "User ID from the IAM Identity Center (optional)",
+			},
+			"enabled_date": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "When the user was last enabled, in RFC 3339 format.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"disabled_date": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "When the user was last disabled, in RFC 3339 format.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"mailbox_provisioned_date": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "When the mailbox of the user was provisioned, in RFC 3339 format.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"mailbox_deprovisioned_date": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "When the mailbox of the user was deprovisioned, in RFC 3339 format.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"mailbox_quota_mb": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: fmt.Sprintf("Maximum size of the mailbox of the user, in MB, between %d and %d. Applied after the user is enabled. If not set, the organization default is used and reported. Removing it from the configuration keeps the current quota.", minMailboxQuotaMB, maxMailboxQuotaMB),
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"mailbox_size_mb": schema.Float64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Current size of the mailbox of the user, in MB. Null while the user has no mailbox.",
+				PlanModifiers: []planmodifier.Float64{
+					float64planmodifier.UseStateForUnknown(),
+				},
+			},
 		},
 	}
 }
@@ -89,16 +294,380 @@ func (r *userResource) Configure(_ context.Context, req resource.ConfigureReques
 		return
 	}
 
-	cfg, ok := req.ProviderData.(aws.Config)
+	pd, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected aws.Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.cfg = cfg
+	r.cfg = pd.cfg
+}
+
+func (r *userResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data userResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.OnDestroy.IsNull() && !data.OnDestroy.IsUnknown() {
+		switch data.OnDestroy.ValueString() {
+		case userOnDestroyDelete, userOnDestroyDisable, userOnDestroyDeregisterAndDelete:
+		default:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("on_destroy"),
+				"Invalid on_destroy value",
+				fmt.Sprintf("on_destroy must be one of %s, %s or %s, got %q.",
+					userOnDestroyDeregisterAndDelete, userOnDestroyDelete, userOnDestroyDisable, data.OnDestroy.ValueString()),
+			)
+		}
+	}
+
+	resp.Diagnostics.Append(validateAliasesConfig(ctx, data.Aliases, data.Email)...)
+	if !data.Aliases.IsNull() && !data.Enabled.IsUnknown() && !data.Enabled.IsNull() && !data.Enabled.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("aliases"),
+			"Aliases require an enabled user",
+			"WorkMail only accepts aliases for enabled users, so aliases cannot be set when enabled is false.",
+		)
+	}
+
+	if !data.MailboxQuotaMB.IsNull() && !data.MailboxQuotaMB.IsUnknown() {
+		quota := data.MailboxQuotaMB.ValueInt64()
+		if quota < minMailboxQuotaMB || quota > maxMailboxQuotaMB {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("mailbox_quota_mb"),
+				"Invalid mailbox quota",
+				fmt.Sprintf("mailbox_quota_mb must be between %d and %d, got %d.", minMailboxQuotaMB, maxMailboxQuotaMB, quota),
+			)
+		}
+		if !data.Enabled.IsUnknown() && !data.Enabled.IsNull() && !data.Enabled.ValueBool() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("mailbox_quota_mb"),
+				"Mailbox quota requires an enabled user",
+				"Only enabled users have a mailbox, so mailbox_quota_mb cannot be set when enabled is false.",
+			)
+		}
+	}
+
+	// Defer validation until the values are known.
+	if data.Role.IsUnknown() || data.Password.IsUnknown() || data.PasswordWO.IsUnknown() || data.IdentityProviderUserID.IsUnknown() {
+		return
+	}
+
+	role := wmtypes.UserRoleUser
+	if !data.Role.IsNull() {
+		role = wmtypes.UserRole(data.Role.ValueString())
+		if !isSettableUserRole(role) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("role"),
+				"Invalid user role",
+				fmt.Sprintf("role must be one of %s, got %q.", formatUserRoles(), role),
+			)
+			return
+		}
+	}
+
+	hasPassword := !data.Password.IsNull() || !data.PasswordWO.IsNull()
+	switch {
+	case !data.Password.IsNull() && !data.PasswordWO.IsNull():
+		resp.Diagnostics.AddAttributeError(
+			path.Root("password_wo"),
+			"Conflicting password attributes",
+			"Only one of password or password_wo can be set.",
+		)
+	case role == wmtypes.UserRoleRemoteUser && hasPassword:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("password"),
+			"Password not allowed for REMOTE_USER",
+			"Users with role REMOTE_USER authenticate against a remote directory and must not have a password.",
+		)
+	case role != wmtypes.UserRoleRemoteUser && !hasPassword && data.IdentityProviderUserID.IsNull():
+		resp.Diagnostics.AddAttributeError(
+			path.Root("password"),
+			"Missing password",
+			fmt.Sprintf("password or password_wo is required for users with role %s, unless identity_provider_user_id is set.", role),
+		)
+	}
+
+	if !data.PasswordWOVersion.IsNull() && data.PasswordWO.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("password_wo_version"),
+			"Missing write-only password",
+			"password_wo_version can only be set together with password_wo.",
+		)
+	}
+}
+
+// setUserEnabled registers the user to WorkMail or deregisters it, and waits
+// until DescribeUser reports the target state. An enabled user whose address
+// differs from the configured email has its primary address changed in place,
+// since registering it again fails. data.Enabled and data.Email are set from
+// the final state.
+func setUserEnabled(ctx context.Context, client *workmail.Client, data *userResourceModel, enabled bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+	orgID := data.OrganizationID.ValueString()
+	userID := data.ID.ValueString()
+
+	current, err := client.DescribeUser(ctx, &workmail.DescribeUserInput{
+		OrganizationId: aws.String(orgID),
+		UserId:         aws.String(userID),
+	})
+	if err != nil {
+		diags.AddError("Error reading WorkMail user", err.Error())
+		return diags
+	}
+	email := data.Email.ValueString()
+	currentEmail := aws.ToString(current.Email)
+
+	want := wmtypes.EntityStateDisabled
+	if enabled {
+		want = wmtypes.EntityStateEnabled
+	}
+
+	switch {
+	case enabled && current.State == wmtypes.EntityStateEnabled:
+		if email != "" && !strings.EqualFold(email, currentEmail) {
+			err := updatePrimaryEmail(ctx, client, orgID, userID, currentEmail, email, data.KeepPreviousEmail.ValueBool())
+			if err != nil {
+				diags.AddError("Error updating WorkMail user primary email address", err.Error())
+				return diags
+			}
+			currentEmail = email
+		}
+		data.Enabled = types.BoolValue(true)
+		data.Email = types.StringValue(currentEmail)
+		return diags
+	case enabled:
+		if email == "" {
+			email, err = defaultUserEmail(ctx, client, orgID, data.Name.ValueString())
+			if err != nil {
+				diags.AddError("Error determining WorkMail user email address", err.Error())
+				return diags
+			}
+		}
+		_, err = client.RegisterToWorkMail(ctx, &workmail.RegisterToWorkMailInput{
+			OrganizationId: aws.String(orgID),
+			EntityId:       aws.String(userID),
+			Email:          aws.String(email),
+		})
+		if err != nil {
+			diags.AddError("Error enabling WorkMail user", err.Error())
+			return diags
+		}
+	case current.State == wmtypes.EntityStateDisabled:
+		data.Enabled = types.BoolValue(false)
+		if data.Email.IsUnknown() {
+			data.Email = optionalStringValue(current.Email)
+		}
+		return diags
+	default:
+		_, err = client.DeregisterFromWorkMail(ctx, &workmail.DeregisterFromWorkMailInput{
+			OrganizationId: aws.String(orgID),
+			EntityId:       aws.String(userID),
+		})
+		if err != nil {
+			diags.AddError("Error disabling WorkMail user", err.Error())
+			return diags
+		}
+	}
+
+	out, err := waitForUserState(ctx, client, orgID, userID, want)
+	if err != nil {
+		diags.AddError(
+			"Error waiting for WorkMail user state",
+			fmt.Sprintf("User %s did not reach state %s: %s", userID, want, err),
+		)
+		return diags
+	}
+	data.Enabled = types.BoolValue(enabled)
+	if enabled || data.Email.IsUnknown() {
+		data.Email = optionalStringValue(out.Email)
+	}
+	return diags
+}
+
+// waitForUserState polls DescribeUser until the user reaches state.
+func waitForUserState(ctx context.Context, client *workmail.Client, organizationID, userID string, state wmtypes.EntityState) (*workmail.DescribeUserOutput, error) {
+	ctx, cancel := context.WithTimeout(ctx, userStateTimeout)
+	defer cancel()
+
+	input := &workmail.DescribeUserInput{
+		OrganizationId: aws.String(organizationID),
+		UserId:         aws.String(userID),
+	}
+	for {
+		out, err := client.DescribeUser(ctx, input)
+		if err == nil && out.State == state {
+			return out, nil
+		} else if err != nil && ctx.Err() == nil {
+			return nil, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("timed out after %s", userStateTimeout)
+		case <-time.After(userStatePollInterval):
+		}
+	}
+}
+
+// defaultUserEmail returns <name>@<default mail domain> of the organization.
+func defaultUserEmail(ctx context.Context, client *workmail.Client, organizationID, name string) (string, error) {
+	out, err := client.DescribeOrganization(ctx, &workmail.DescribeOrganizationInput{
+		OrganizationId: aws.String(organizationID),
+	})
+	if err != nil {
+		return "", err
+	}
+	if aws.ToString(out.DefaultMailDomain) == "" {
+		return "", fmt.Errorf("organization %s has no default mail domain; set email explicitly", organizationID)
+	}
+	return name + "@" + *out.DefaultMailDomain, nil
+}
+
+// ModifyPlan marks the attributes that an update changes as unknown: the
+// lifecycle dates, mailbox_size_mb and an unconfigured mailbox_quota_mb when
+// enabled changes, and unconfigured aliases when email or enabled changes.
+// It also warns when configured aliases use a domain that is not registered
+// with the organization yet.
+func (r *userResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state userResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The lifecycle and mailbox details are carried over from state, except
+	// when the user is registered or deregistered, which changes them.
+	if !req.State.Raw.IsNull() && entityEnabledValue(plan.Enabled) != entityEnabledValue(state.Enabled) {
+		for _, attr := range []string{"enabled_date", "disabled_date", "mailbox_provisioned_date", "mailbox_deprovisioned_date"} {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(attr), types.StringUnknown())...)
+		}
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("mailbox_size_mb"), types.Float64Unknown())...)
+		var quota types.Int64
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("mailbox_quota_mb"), &quota)...)
+		if quota.IsNull() {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("mailbox_quota_mb"), types.Int64Unknown())...)
+		}
+	}
+
+	if !req.State.Raw.IsNull() && (!plan.Email.Equal(state.Email) || entityEnabledValue(plan.Enabled) != entityEnabledValue(state.Enabled)) {
+		resp.Diagnostics.Append(planAliasesAfterAddressChange(ctx, req.Config, &resp.Plan)...)
+	}
+
+	if plan.Aliases.Equal(state.Aliases) {
+		return
+	}
+	client := workmail.NewFromConfig(r.cfg)
+	resp.Diagnostics.Append(warnUnregisteredAliasDomains(ctx, client, plan.OrganizationID, plan.Aliases)...)
+}
+
+// entityEnabledValue returns the effective value of enabled on a user or
+// group, which defaults to true when it is not set.
+func entityEnabledValue(enabled types.Bool) bool {
+	return enabled.IsNull() || enabled.IsUnknown() || enabled.ValueBool()
+}
+
+// configuredPassword returns password, or the write-only password_wo read
+// from the configuration, or an empty string if neither is set.
+func (r *userResource) configuredPassword(ctx context.Context, config tfsdk.Config, data *userResourceModel) (string, diag.Diagnostics) {
+	if !data.Password.IsNull() {
+		return data.Password.ValueString(), nil
+	}
+	var passwordWO types.String
+	diags := config.GetAttribute(ctx, path.Root("password_wo"), &passwordWO)
+	return passwordWO.ValueString(), diags
+}
+
+// isSettableUserRole reports whether role can be assigned to a user.
+func isSettableUserRole(role wmtypes.UserRole) bool {
+	for _, r := range userRoles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// formatUserRoles renders userRoles for diagnostics.
+func formatUserRoles() string {
+	names := make([]string, 0, len(userRoles))
+	for _, r := range userRoles {
+		names = append(names, string(r))
+	}
+	return strings.Join(names, ", ")
+}
+
+// optionalStringValue converts an optional API string, treating empty as unset.
+func optionalStringValue(v *string) types.String {
+	if v == nil || *v == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(*v)
+}
+
+// setUserProfileUpdate copies the directory attributes that differ between
+// plan and state into input. Attributes removed from the configuration are
+// cleared with an empty string. It reports whether anything was set.
+func setUserProfileUpdate(input *workmail.UpdateUserInput, plan, state *userResourceModel) bool {
+	changed := false
+	set := func(planValue, stateValue types.String, dst **string) {
+		if planValue.IsUnknown() || planValue.Equal(stateValue) {
+			return
+		}
+		*dst = aws.String(planValue.ValueString())
+		changed = true
+	}
+
+	set(plan.Initials, state.Initials, &input.Initials)
+	set(plan.Telephone, state.Telephone, &input.Telephone)
+	set(plan.Street, state.Street, &input.Street)
+	set(plan.City, state.City, &input.City)
+	set(plan.ZipCode, state.ZipCode, &input.ZipCode)
+	set(plan.Country, state.Country, &input.Country)
+	set(plan.Office, state.Office, &input.Office)
+	set(plan.Company, state.Company, &input.Company)
+	set(plan.Department, state.Department, &input.Department)
+	set(plan.JobTitle, state.JobTitle, &input.JobTitle)
+	set(plan.IdentityProviderUserID, state.IdentityProviderUserID, &input.IdentityProviderUserId)
+
+	if !plan.HiddenFromGlobalAddressList.IsUnknown() && !plan.HiddenFromGlobalAddressList.IsNull() &&
+		!plan.HiddenFromGlobalAddressList.Equal(state.HiddenFromGlobalAddressList) {
+		input.HiddenFromGlobalAddressList = aws.Bool(plan.HiddenFromGlobalAddressList.ValueBool())
+		changed = true
+	}
+
+	return changed
+}
+
+// passwordResetNeeded reports whether the password must be reset: when
+// password changed, or when password_wo_version was set or bumped. Write-only
+// values cannot be compared with state, and removing password_wo_version,
+// for example when switching to IAM Identity Center, leaves the password as is.
+func passwordResetNeeded(plan, state *userResourceModel) bool {
+	if !plan.Password.IsNull() && !plan.Password.Equal(state.Password) {
+		return true
+	}
+	return !plan.PasswordWOVersion.IsNull() && !plan.PasswordWOVersion.Equal(state.PasswordWOVersion)
+}
+
+// isEntityNotFound reports whether err means the user or group does not exist.
+func isEntityNotFound(err error) bool {
+	if err == nil {
+		return false
+	}
+	return strings.Contains(err.Error(), "EntityNotFoundException")
 }
 
 func isEntityStateException(err error) bool {
@@ -118,7 +687,17 @@ func (r *userResource) Create(ctx context.Context, req resource.CreateRequest, r
 		OrganizationId: aws.String(data.OrganizationID.ValueString()),
 		Name:           aws.String(data.Name.ValueString()),
 		DisplayName:    aws.String(data.DisplayName.ValueString()),
-		Password:       aws.String(data.Password.ValueString()),
+	}
+	password, diags := r.configuredPassword(ctx, req.Config, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if password != "" {
+		input.Password = aws.String(password)
+	}
+	if !data.Role.IsNull() && !data.Role.IsUnknown() {
+		input.Role = wmtypes.UserRole(data.Role.ValueString())
 	}
 	if !data.FirstName.IsNull() && data.FirstName.ValueString() != "" {
 		input.FirstName = aws.String(data.FirstName.ValueString())
@@ -126,6 +705,10 @@ func (r *userResource) Create(ctx context.Context, req resource.CreateRequest, r
 	if !data.LastName.IsNull() && data.LastName.ValueString() != "" {
 		input.LastName = aws.String(data.LastName.ValueString())
 	}
+	if !data.IdentityProviderUserID.IsNull() && data.IdentityProviderUserID.ValueString() != "" {
+		input.IdentityProviderUserId = aws.String(data.IdentityProviderUserID.ValueString())
+	}
+	input.HiddenFromGlobalAddressList = data.HiddenFromGlobalAddressList.ValueBool()
 	out, err := client.CreateUser(ctx, input)
 	if err != nil {
 		if isEntityStateException(err) {
@@ -139,25 +722,58 @@ func (r *userResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 	data.ID = types.StringValue(*out.UserId)
+	data.HiddenFromGlobalAddressList = types.BoolValue(input.HiddenFromGlobalAddressList)
+	if input.Role == "" {
+		data.Role = types.StringValue(string(wmtypes.UserRoleUser))
+	}
+
+	// CreateUser only accepts a few directory attributes; apply the rest now.
+	profileInput := &workmail.UpdateUserInput{
+		OrganizationId: aws.String(data.OrganizationID.ValueString()),
+		UserId:         aws.String(data.ID.ValueString()),
+	}
+	if setUserProfileUpdate(profileInput, &data, &userResourceModel{
+		HiddenFromGlobalAddressList: data.HiddenFromGlobalAddressList,
+		IdentityProviderUserID:      data.IdentityProviderUserID,
+	}) {
+		if _, err := client.UpdateUser(ctx, profileInput); err != nil {
+			resp.Diagnostics.AddError("Error setting WorkMail user attributes", err.Error())
+			// Keep the created user in state so it is not orphaned.
+			clearUnknownUserDetails(&data)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			return
+		}
+	}
 
 	// Enable or disable user if needed
 	enabled := true
-	if !data.Enabled.IsNull() {
+	if !data.Enabled.IsNull() && !data.Enabled.IsUnknown() {
 		enabled = data.Enabled.ValueBool()
 	}
-	if enabled {
-		_, _ = client.RegisterToWorkMail(ctx, &workmail.RegisterToWorkMailInput{
-			OrganizationId: aws.String(data.OrganizationID.ValueString()),
-			EntityId:       aws.String(data.ID.ValueString()),
-			Email:          aws.String(data.Email.ValueString()),
-		})
-	} else {
-		_, _ = client.DeregisterFromWorkMail(ctx, &workmail.DeregisterFromWorkMailInput{
-			OrganizationId: aws.String(data.OrganizationID.ValueString()),
-			EntityId:       aws.String(data.ID.ValueString()),
-		})
+	resp.Diagnostics.Append(setUserEnabled(ctx, client, &data, enabled)...)
+	// The user exists even if registration failed, so keep it in state.
+	if data.Enabled.IsUnknown() {
+		data.Enabled = types.BoolValue(false)
 	}
-
+	if data.Email.IsUnknown() {
+		data.Email = types.StringNull()
+	}
+	if !resp.Diagnostics.HasError() && !data.MailboxQuotaMB.IsNull() && !data.MailboxQuotaMB.IsUnknown() {
+		if err := updateMailboxQuota(ctx, client, &data); err != nil {
+			resp.Diagnostics.AddError("Error setting WorkMail mailbox quota", err.Error())
+		}
+	}
+	if !resp.Diagnostics.HasError() {
+		aliases, diags := setEntityAliases(ctx, client, req.Config, data.OrganizationID.ValueString(), data.ID.ValueString(), data.Email.ValueString())
+		resp.Diagnostics.Append(diags...)
+		data.Aliases = aliases
+	}
+	if !resp.Diagnostics.HasError() {
+		if err := refreshUserDetails(ctx, client, &data); err != nil {
+			resp.Diagnostics.AddError("Error reading WorkMail user details", err.Error())
+		}
+	}
+	clearUnknownUserDetails(&data)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -176,9 +792,26 @@ func (r *userResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	}
 	out, err := client.DescribeUser(ctx, input)
 	if err != nil {
+		// If the user was deleted outside Terraform, remove it from state
+		if isEntityNotFound(err) {
+			resp.Diagnostics.AddWarning(
+				"WorkMail user not found",
+				"User "+data.ID.ValueString()+" no longer exists in organization "+data.OrganizationID.ValueString()+" and has been removed from state.",
+			)
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError("Error reading WorkMail user", err.Error())
 		return
 	}
+	if out.State == wmtypes.EntityStateDeleted {
+		resp.Diagnostics.AddWarning(
+			"WorkMail user deleted",
+			"User "+data.ID.ValueString()+" is in the DELETED state in organization "+data.OrganizationID.ValueString()+" and has been removed from state.",
+		)
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if out != nil && out.UserId != nil && *out.UserId != "" {
 		data.ID = types.StringValue(*out.UserId)
 	}
@@ -194,34 +827,68 @@ func (r *userResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	if out != nil && out.State != "" {
 		data.Enabled = types.BoolValue(out.State == "ENABLED")
 	}
+	if out != nil && out.UserRole != "" {
+		data.Role = types.StringValue(string(out.UserRole))
+	}
 	if out != nil && out.FirstName != nil {
 		data.FirstName = types.StringValue(*out.FirstName)
 	}
 	if out != nil && out.LastName != nil {
 		data.LastName = types.StringValue(*out.LastName)
 	}
+	if out != nil {
+		data.Initials = optionalStringValue(out.Initials)
+		data.Telephone = optionalStringValue(out.Telephone)
+		data.Street = optionalStringValue(out.Street)
+		data.City = optionalStringValue(out.City)
+		data.ZipCode = optionalStringValue(out.ZipCode)
+		data.Country = optionalStringValue(out.Country)
+		data.Office = optionalStringValue(out.Office)
+		data.Company = optionalStringValue(out.Company)
+		data.Department = optionalStringValue(out.Department)
+		data.JobTitle = optionalStringValue(out.JobTitle)
+		data.HiddenFromGlobalAddressList = types.BoolValue(out.HiddenFromGlobalAddressList)
+		data.IdentityProviderUserID = optionalStringValue(out.IdentityProviderUserId)
+		setUserLifecycleDetails(&data, out)
+	}
+	data.MailboxQuotaMB, data.MailboxSizeMB, err = getMailboxDetails(ctx, client, data.OrganizationID.ValueString(), data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading WorkMail mailbox details", err.Error())
+		return
+	}
+	aliases, err := readEntityAliases(ctx, client, data.OrganizationID.ValueString(), data.ID.ValueString(), data.Email.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading WorkMail user aliases", err.Error())
+		return
+	}
+	aliasSet, diags := types.SetValueFrom(ctx, types.StringType, aliases)
+	resp.Diagnostics.Append(diags...)
+	data.Aliases = aliasSet
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *userResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data userResourceModel
+	var data, stateData userResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateData)...)
 	if data.ID.IsNull() || data.ID.ValueString() == "" {
-		var stateData userResourceModel
-		resp.Diagnostics.Append(req.State.Get(ctx, &stateData)...)
 		data.ID = stateData.ID
 	}
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	// Only display name and password can be updated
 	client := workmail.NewFromConfig(r.cfg)
 
-	if !data.DisplayName.IsNull() || !data.FirstName.IsNull() || !data.LastName.IsNull() {
-		updateInput := &workmail.UpdateUserInput{
-			OrganizationId: aws.String(data.OrganizationID.ValueString()),
-			UserId:         aws.String(data.ID.ValueString()),
-		}
+	updateInput := &workmail.UpdateUserInput{
+		OrganizationId: aws.String(data.OrganizationID.ValueString()),
+		UserId:         aws.String(data.ID.ValueString()),
+	}
+	profileChanged := setUserProfileUpdate(updateInput, &data, &stateData)
+	if !data.Role.IsUnknown() && !data.Role.IsNull() && !data.Role.Equal(stateData.Role) {
+		updateInput.Role = wmtypes.UserRole(data.Role.ValueString())
+		profileChanged = true
+	}
+	if profileChanged || !data.DisplayName.IsNull() || !data.FirstName.IsNull() || !data.LastName.IsNull() {
 		if !data.DisplayName.IsNull() {
 			updateInput.DisplayName = aws.String(data.DisplayName.ValueString())
 		}
@@ -244,11 +911,24 @@ func (r *userResource) Update(ctx context.Context, req resource.UpdateRequest, r
 			return
 		}
 	}
-	if !data.Password.IsNull() {
+	if passwordResetNeeded(&data, &stateData) {
+		password, diags := r.configuredPassword(ctx, req.Config, &data)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if password == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("password_wo"),
+				"Missing write-only password",
+				"password_wo_version changed but password_wo is not set, so the password cannot be reset.",
+			)
+			return
+		}
 		_, err := client.ResetPassword(ctx, &workmail.ResetPasswordInput{
 			OrganizationId: aws.String(data.OrganizationID.ValueString()),
 			UserId:         aws.String(data.ID.ValueString()),
-			Password:       aws.String(data.Password.ValueString()),
+			Password:       aws.String(password),
 		})
 		if err != nil {
 			resp.Diagnostics.AddError("Error resetting WorkMail user password", err.Error())
@@ -257,24 +937,34 @@ func (r *userResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	}
 	// Enable or disable user if needed
 	enabled := true
-	if !data.Enabled.IsNull() {
+	if !data.Enabled.IsNull() && !data.Enabled.IsUnknown() {
 		enabled = data.Enabled.ValueBool()
 	}
-	if enabled {
-		_, _ = client.RegisterToWorkMail(ctx, &workmail.RegisterToWorkMailInput{
-			OrganizationId: aws.String(data.OrganizationID.ValueString()),
-			EntityId:       aws.String(data.ID.ValueString()),
-			Email:          aws.String(data.Email.ValueString()),
-		})
-	} else {
-		_, _ = client.DeregisterFromWorkMail(ctx, &workmail.DeregisterFromWorkMailInput{
-			OrganizationId: aws.String(data.OrganizationID.ValueString()),
-			EntityId:       aws.String(data.ID.ValueString()),
-		})
+	resp.Diagnostics.Append(setUserEnabled(ctx, client, &data, enabled)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !data.MailboxQuotaMB.IsNull() && !data.MailboxQuotaMB.IsUnknown() && !data.MailboxQuotaMB.Equal(stateData.MailboxQuotaMB) {
+		if err := updateMailboxQuota(ctx, client, &data); err != nil {
+			resp.Diagnostics.AddError("Error updating WorkMail mailbox quota", err.Error())
+			return
+		}
+	}
+	aliases, diags := setEntityAliases(ctx, client, req.Config, data.OrganizationID.ValueString(), data.ID.ValueString(), data.Email.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Aliases = aliases
+	if err := refreshUserDetails(ctx, client, &data); err != nil {
+		resp.Diagnostics.AddError("Error reading WorkMail user details", err.Error())
+		return
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// Delete applies on_destroy. WorkMail only deletes disabled users, so the
+// default first deregisters the user and waits for it to be DISABLED.
 func (r *userResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var data userResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -283,11 +973,61 @@ func (r *userResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	}
 
 	client := workmail.NewFromConfig(r.cfg)
+	orgID := data.OrganizationID.ValueString()
+	userID := data.ID.ValueString()
+
+	onDestroy := userOnDestroyDeregisterAndDelete
+	if !data.OnDestroy.IsNull() {
+		onDestroy = data.OnDestroy.ValueString()
+	}
+
+	if onDestroy != userOnDestroyDelete {
+		out, err := client.DescribeUser(ctx, &workmail.DescribeUserInput{
+			OrganizationId: aws.String(orgID),
+			UserId:         aws.String(userID),
+		})
+		if err != nil {
+			if isEntityNotFound(err) {
+				return
+			}
+			resp.Diagnostics.AddError("Error reading WorkMail user", err.Error())
+			return
+		}
+		switch out.State {
+		case wmtypes.EntityStateDeleted:
+			return
+		case wmtypes.EntityStateEnabled:
+			_, err := client.DeregisterFromWorkMail(ctx, &workmail.DeregisterFromWorkMailInput{
+				OrganizationId: aws.String(orgID),
+				EntityId:       aws.String(userID),
+			})
+			if err != nil && !isEntityNotFound(err) {
+				resp.Diagnostics.AddError("Error disabling WorkMail user", err.Error())
+				return
+			}
+			if _, err := waitForUserState(ctx, client, orgID, userID, wmtypes.EntityStateDisabled); err != nil {
+				resp.Diagnostics.AddError(
+					"Error waiting for WorkMail user state",
+					fmt.Sprintf("User %s did not reach state %s: %s", userID, wmtypes.EntityStateDisabled, err),
+				)
+				return
+			}
+		}
+	}
+
+	if onDestroy == userOnDestroyDisable {
+		resp.Diagnostics.AddWarning(
+			"WorkMail user kept",
+			fmt.Sprintf("User %s was disabled and removed from Terraform state, but it and its mailbox remain in organization %s because on_destroy is %q.", userID, orgID, onDestroy),
+		)
+		return
+	}
+
 	_, err := client.DeleteUser(ctx, &workmail.DeleteUserInput{
-		OrganizationId: aws.String(data.OrganizationID.ValueString()),
-		UserId:         aws.String(data.ID.ValueString()),
+		OrganizationId: aws.String(orgID),
+		UserId:         aws.String(userID),
 	})
-	if err != nil {
+	if err != nil && !isEntityNotFound(err) {
 		resp.Diagnostics.AddError("Error deleting WorkMail user", err.Error())
 	}
 }
@@ -297,10 +1037,16 @@ func (r *userResource) ImportState(ctx context.Context, req resource.ImportState
 	if len(parts) != 2 {
 		resp.Diagnostics.AddError(
 			"Invalid import ID format",
-			"Expected import ID format: <organization_id>,<user_id>",
+			"Expected import ID format: <organization_id>,<user_id>, <organization_id>,<name> or <organization_id>,<email>",
 		)
 		return
 	}
+
+	id, err := resolveImportEntityID(ctx, workmail.NewFromConfig(r.cfg), parts[0], parts[1], wmtypes.EntityTypeUser)
+	if err != nil {
+		resp.Diagnostics.AddError("Error resolving WorkMail user to import", err.Error())
+		return
+	}
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("organization_id"), parts[0])...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
 }
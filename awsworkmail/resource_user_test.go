@@ -0,0 +1,470 @@
+package awsworkmail
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/workmail"
+	wmtypes "github.com/aws/aws-sdk-go-v2/service/workmail/types"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccUser_profile(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserProfileConfig(`
+  job_title        = "Engineer"
+  department       = "Platform"
+  city             = "Berlin"
+  mailbox_quota_mb = 2048
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awsworkmail_user.test", "job_title", "Engineer"),
+					resource.TestCheckResourceAttr("awsworkmail_user.test", "department", "Platform"),
+					resource.TestCheckResourceAttr("awsworkmail_user.test", "city", "Berlin"),
+					resource.TestCheckResourceAttr("awsworkmail_user.test", "mailbox_quota_mb", "2048"),
+					resource.TestCheckResourceAttrSet("awsworkmail_user.test", "mailbox_size_mb"),
+					resource.TestCheckResourceAttr("awsworkmail_user.test", "hidden_from_global_address_list", "false"),
+				),
+			},
+			{
+				Config: testAccUserProfileConfig(`
+  job_title                       = "Manager"
+  hidden_from_global_address_list = true
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awsworkmail_user.test", "job_title", "Manager"),
+					// Removing the quota from the configuration keeps it.
+					resource.TestCheckResourceAttr("awsworkmail_user.test", "mailbox_quota_mb", "2048"),
+					resource.TestCheckNoResourceAttr("awsworkmail_user.test", "department"),
+					resource.TestCheckNoResourceAttr("awsworkmail_user.test", "city"),
+					resource.TestCheckResourceAttr("awsworkmail_user.test", "hidden_from_global_address_list", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testAccUserProfileConfig(profile string) string {
+	return fmt.Sprintf(`
+resource "awsworkmail_organization" "test" {
+  alias = "tfacc-user-profile"
+}
+
+resource "awsworkmail_user" "test" {
+  organization_id = awsworkmail_organization.test.id
+  name            = "tfacc.profile"
+  display_name    = "Tfacc Profile"
+  password        = "ChangeMe123!"
+%s}
+`, profile)
+}
+
+func TestAccUser_email(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserEmailConfig("tfacc.email", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awsworkmail_user.test", "email", "tfacc.email@tfacc-user-email.awsapps.com"),
+				),
+			},
+			{
+				Config: testAccUserEmailConfig("tfacc.renamed", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awsworkmail_user.test", "email", "tfacc.renamed@tfacc-user-email.awsapps.com"),
+					resource.TestCheckTypeSetElemAttr("awsworkmail_user.test", "aliases.*", "tfacc.email@tfacc-user-email.awsapps.com"),
+				),
+			},
+		},
+	})
+}
+
+func testAccUserEmailConfig(local string, keepPrevious bool) string {
+	return fmt.Sprintf(`
+resource "awsworkmail_organization" "test" {
+  alias = "tfacc-user-email"
+}
+
+resource "awsworkmail_user" "test" {
+  organization_id              = awsworkmail_organization.test.id
+  name                         = "tfacc.email"
+  display_name                 = "Tfacc Email"
+  password                     = "ChangeMe123!"
+  email                        = "%s@${awsworkmail_organization.test.alias}.awsapps.com"
+  keep_previous_email_as_alias = %t
+}
+`, local, keepPrevious)
+}
+
+func TestAccUser_aliases(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserAliasesConfig(`"info", "sales"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awsworkmail_user.test", "aliases.#", "2"),
+					resource.TestCheckTypeSetElemAttr("awsworkmail_user.test", "aliases.*", "sales@tfacc-user-aliases.awsapps.com"),
+				),
+			},
+			{
+				Config: testAccUserAliasesConfig(`"info"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("awsworkmail_user.test", "aliases.#", "1"),
+					resource.TestCheckTypeSetElemAttr("awsworkmail_user.test", "aliases.*", "info@tfacc-user-aliases.awsapps.com"),
+				),
+			},
+		},
+	})
+}
+
+func testAccUserAliasesConfig(locals string) string {
+	return fmt.Sprintf(`
+resource "awsworkmail_organization" "test" {
+  alias = "tfacc-user-aliases"
+}
+
+resource "awsworkmail_user" "test" {
+  organization_id = awsworkmail_organization.test.id
+  name            = "tfacc.aliases"
+  display_name    = "Tfacc Aliases"
+  password        = "ChangeMe123!"
+  aliases         = [for local in [%s] : "${local}@${awsworkmail_organization.test.alias}.awsapps.com"]
+}
+`, locals)
+}
+
+func TestAccUser_passwordWO(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserPasswordWOConfig("ChangeMe123!", 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("awsworkmail_user.test", "password"),
+					resource.TestCheckNoResourceAttr("awsworkmail_user.test", "password_wo"),
+					resource.TestCheckResourceAttr("awsworkmail_user.test", "password_wo_version", "1"),
+				),
+			},
+			{
+				Config: testAccUserPasswordWOConfig("ChangeMe456!", 2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("awsworkmail_user.test", "password_wo"),
+					resource.TestCheckResourceAttr("awsworkmail_user.test", "password_wo_version", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccUserPasswordWOConfig(password string, version int) string {
+	return fmt.Sprintf(`
+resource "awsworkmail_organization" "test" {
+  alias = "tfacc-user-password-wo"
+}
+
+resource "awsworkmail_user" "test" {
+  organization_id     = awsworkmail_organization.test.id
+  name                = "tfacc.passwordwo"
+  display_name        = "Tfacc PasswordWO"
+  password_wo         = %q
+  password_wo_version = %d
+}
+`, password, version)
+}
+
+func TestAccUser_roleValidation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccUserRoleConfig(`role = "REMOTE_USER"`, `password = "ChangeMe123!"`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Password not allowed for REMOTE_USER`),
+			},
+			{
+				Config:      testAccUserRoleConfig(`role = "USER"`, ""),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Missing password`),
+			},
+			{
+				Config:      testAccUserRoleConfig(`password_wo = "ChangeMe123!"`, `password = "ChangeMe123!"`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Conflicting password attributes`),
+			},
+			{
+				Config:      testAccUserRoleConfig(`password_wo_version = 1`, `password = "ChangeMe123!"`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Missing write-only password`),
+			},
+			{
+				Config:      testAccUserRoleConfig(`role = "SYSTEM_USER"`, `password = "ChangeMe123!"`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid user role`),
+			},
+		},
+	})
+}
+
+func TestAccUser_onDestroyValidation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccUserRoleConfig(`on_destroy = "archive"`, `password = "ChangeMe123!"`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid on_destroy value`),
+			},
+		},
+	})
+}
+
+func TestAccUser_quotaValidation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccUserRoleConfig(`mailbox_quota_mb = 60000`, `password = "ChangeMe123!"`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid mailbox quota`),
+			},
+		},
+	})
+}
+
+func testAccUserRoleConfig(role, password string) string {
+	return fmt.Sprintf(`
+resource "awsworkmail_user" "test" {
+  organization_id = "m-00000000000000000000000000000000"
+  name            = "tfacc.role"
+  display_name    = "Tfacc Role"
+  %s
+  %s
+}
+`, role, password)
+}
+
+func TestSetUserProfileUpdate(t *testing.T) {
+	state := &userResourceModel{
+		City:                        types.StringValue("Berlin"),
+		Department:                  types.StringValue("Platform"),
+		HiddenFromGlobalAddressList: types.BoolValue(false),
+	}
+	plan := &userResourceModel{
+		City:                        types.StringValue("Berlin"),
+		Department:                  types.StringNull(),
+		JobTitle:                    types.StringValue("Engineer"),
+		Office:                      types.StringUnknown(),
+		HiddenFromGlobalAddressList: types.BoolValue(true),
+	}
+
+	input := &workmail.UpdateUserInput{}
+	if !setUserProfileUpdate(input, plan, state) {
+		t.Fatal("expected changes to be reported")
+	}
+	if input.City != nil {
+		t.Errorf("unchanged city was sent: %q", aws.ToString(input.City))
+	}
+	if input.Department == nil || *input.Department != "" {
+		t.Errorf("removed department should be cleared, got %v", input.Department)
+	}
+	if aws.ToString(input.JobTitle) != "Engineer" {
+		t.Errorf("job title = %q, want Engineer", aws.ToString(input.JobTitle))
+	}
+	if input.Office != nil {
+		t.Errorf("unknown office was sent: %q", aws.ToString(input.Office))
+	}
+	if input.HiddenFromGlobalAddressList == nil || !*input.HiddenFromGlobalAddressList {
+		t.Errorf("hidden_from_global_address_list not sent")
+	}
+
+	if setUserProfileUpdate(&workmail.UpdateUserInput{}, state, state) {
+		t.Error("expected no changes for identical plan and state")
+	}
+}
+
+func TestPasswordResetNeeded(t *testing.T) {
+	cases := []struct {
+		name        string
+		plan, state userResourceModel
+		want        bool
+	}{
+		{
+			name:  "unchanged password",
+			plan:  userResourceModel{Password: types.StringValue("a"), PasswordWOVersion: types.Int64Null()},
+			state: userResourceModel{Password: types.StringValue("a"), PasswordWOVersion: types.Int64Null()},
+		},
+		{
+			name:  "changed password",
+			plan:  userResourceModel{Password: types.StringValue("b"), PasswordWOVersion: types.Int64Null()},
+			state: userResourceModel{Password: types.StringValue("a"), PasswordWOVersion: types.Int64Null()},
+			want:  true,
+		},
+		{
+			name:  "bumped version",
+			plan:  userResourceModel{Password: types.StringNull(), PasswordWOVersion: types.Int64Value(2)},
+			state: userResourceModel{Password: types.StringNull(), PasswordWOVersion: types.Int64Value(1)},
+			want:  true,
+		},
+		{
+			name:  "removed version",
+			plan:  userResourceModel{Password: types.StringNull(), PasswordWOVersion: types.Int64Null()},
+			state: userResourceModel{Password: types.StringNull(), PasswordWOVersion: types.Int64Value(1)},
+		},
+	}
+	for _, tc := range cases {
+		if got := passwordResetNeeded(&tc.plan, &tc.state); got != tc.want {
+			t.Errorf("%s: passwordResetNeeded() = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+// testUserModel returns a user in organization m-1 with the given email.
+func testUserModel(email string) *userResourceModel {
+	return &userResourceModel{
+		ID:                types.StringValue("u-1"),
+		OrganizationID:    types.StringValue("m-1"),
+		Name:              types.StringValue("jane"),
+		Email:             types.StringValue(email),
+		Enabled:           types.BoolValue(true),
+		KeepPreviousEmail: types.BoolNull(),
+	}
+}
+
+func TestSetUserEnabled_alreadyEnabled(t *testing.T) {
+	stub := newStubWorkMail(map[string]wmtypes.EntityState{"u-1": wmtypes.EntityStateEnabled})
+	stub.emails["u-1"] = "Jane@Example.com"
+
+	data := testUserModel("jane@example.com")
+	diags := setUserEnabled(context.Background(), stub.client(t), data, true)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if stub.calls["RegisterToWorkMail"] != 0 || stub.calls["UpdatePrimaryEmailAddress"] != 0 {
+		t.Errorf("calls = %v, want no registration or address change for an enabled user with the same email", stub.calls)
+	}
+	if data.Email.ValueString() != "Jane@Example.com" || !data.Enabled.ValueBool() {
+		t.Errorf("email = %s, enabled = %s", data.Email, data.Enabled)
+	}
+}
+
+func TestSetUserEnabled_changesEmailInPlace(t *testing.T) {
+	stub := newStubWorkMail(map[string]wmtypes.EntityState{"u-1": wmtypes.EntityStateEnabled})
+	stub.emails["u-1"] = "jane@old.com"
+
+	data := testUserModel("jane@new.com")
+	diags := setUserEnabled(context.Background(), stub.client(t), data, true)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if stub.calls["RegisterToWorkMail"] != 0 || stub.calls["UpdatePrimaryEmailAddress"] != 1 {
+		t.Errorf("calls = %v, want one UpdatePrimaryEmailAddress and no registration", stub.calls)
+	}
+	if stub.emails["u-1"] != "jane@new.com" || data.Email.ValueString() != "jane@new.com" {
+		t.Errorf("email = %q in WorkMail and %s in state, want jane@new.com", stub.emails["u-1"], data.Email)
+	}
+}
+
+func TestSetUserEnabled_registers(t *testing.T) {
+	stub := newStubWorkMail(map[string]wmtypes.EntityState{"u-1": wmtypes.EntityStateDisabled})
+
+	data := testUserModel("jane@example.com")
+	diags := setUserEnabled(context.Background(), stub.client(t), data, true)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if stub.users["u-1"] != wmtypes.EntityStateEnabled || data.Email.ValueString() != "jane@example.com" {
+		t.Errorf("user is %s with email %s, want ENABLED with jane@example.com", stub.users["u-1"], data.Email)
+	}
+}
+
+func TestSetUserEnabled_registerError(t *testing.T) {
+	stub := newStubWorkMail(map[string]wmtypes.EntityState{"u-1": wmtypes.EntityStateDisabled})
+	stub.failures["u-1"] = "EmailAddressInUseException"
+
+	diags := setUserEnabled(context.Background(), stub.client(t), testUserModel("jane@example.com"), true)
+	errs := diagSummaries(diags, diag.SeverityError)
+	if len(errs) != 1 || !strings.HasPrefix(errs[0], "Error enabling WorkMail user") || !strings.Contains(errs[0], "EmailAddressInUseException") {
+		t.Errorf("errors = %q, want the RegisterToWorkMail error", errs)
+	}
+}
+
+func TestSetUserEnabled_waitTimesOut(t *testing.T) {
+	stub := newStubWorkMail(map[string]wmtypes.EntityState{"u-1": wmtypes.EntityStateEnabled})
+	stub.emails["u-1"] = "jane@example.com"
+	// Deregistration is accepted but the user never becomes DISABLED.
+	stub.stuck["u-1"] = true
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	diags := setUserEnabled(ctx, stub.client(t), testUserModel("jane@example.com"), false)
+	errs := diagSummaries(diags, diag.SeverityError)
+	if len(errs) != 1 || !strings.HasPrefix(errs[0], "Error waiting for WorkMail user state") || !strings.Contains(errs[0], "DISABLED") {
+		t.Errorf("errors = %q, want a timeout waiting for DISABLED", errs)
+	}
+}
+
+// readTestResource runs Read on r with a state holding only id and
+// organization_id, and returns the response.
+func readTestResource(t *testing.T, r fwresource.Resource, id string) *fwresource.ReadResponse {
+	t.Helper()
+	ctx := context.Background()
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}
+	diags := state.SetAttribute(ctx, path.Root("id"), id)
+	diags.Append(state.SetAttribute(ctx, path.Root("organization_id"), "m-1")...)
+	if diags.HasError() {
+		t.Fatalf("building state: %v", diags)
+	}
+
+	resp := &fwresource.ReadResponse{State: state}
+	r.Read(ctx, fwresource.ReadRequest{State: state}, resp)
+	return resp
+}
+
+func TestUserRead_removesMissingUser(t *testing.T) {
+	stub := newStubWorkMail(map[string]wmtypes.EntityState{"u-deleted": wmtypes.EntityStateDeleted})
+
+	for id, summary := range map[string]string{
+		"u-gone":    "WorkMail user not found",
+		"u-deleted": "WorkMail user deleted",
+	} {
+		resp := readTestResource(t, &userResource{cfg: stub.config(t)}, id)
+		if !resp.State.Raw.IsNull() {
+			t.Errorf("%s: user was not removed from state", id)
+		}
+		if warnings := diagSummaries(resp.Diagnostics, diag.SeverityWarning); len(warnings) != 1 || !strings.HasPrefix(warnings[0], summary) {
+			t.Errorf("%s: warnings = %q, want one %q", id, warnings, summary)
+		}
+		if resp.Diagnostics.HasError() {
+			t.Errorf("%s: unexpected errors: %v", id, resp.Diagnostics)
+		}
+	}
+}
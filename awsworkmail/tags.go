@@ -0,0 +1,118 @@
+package awsworkmail
+
+import (
+	"context"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/workmail"
+	wmtypes "github.com/aws/aws-sdk-go-v2/service/workmail/types"
+)
+
+// mergeTags returns the provider default tags overlaid with the resource tags.
+// Resource tags win when both define the same key.
+func mergeTags(defaultTags, tags map[string]string) map[string]string {
+	merged := make(map[string]string, len(defaultTags)+len(tags))
+	for k, v := range defaultTags {
+		merged[k] = v
+	}
+	for k, v := range tags {
+		merged[k] = v
+	}
+	return merged
+}
+
+// resourceTags derives the resource-level tags from the tags found on AWS.
+// Keys inherited unchanged from the provider default tags are dropped unless
+// they were also configured on the resource, so that only tags owned by the
+// resource (including ones added outside Terraform) show up as drift.
+func resourceTags(remote, configured, defaultTags map[string]string) map[string]string {
+	tags := map[string]string{}
+	for k, v := range remote {
+		if _, ok := configured[k]; ok {
+			tags[k] = v
+			continue
+		}
+		if dv, ok := defaultTags[k]; ok && dv == v {
+			continue
+		}
+		tags[k] = v
+	}
+	return tags
+}
+
+// diffTags returns the tags to add or change and the keys to remove in order
+// to go from oldTags to newTags. Removed keys are sorted for stable API calls.
+func diffTags(oldTags, newTags map[string]string) (map[string]string, []string) {
+	upsert := map[string]string{}
+	for k, v := range newTags {
+		if ov, ok := oldTags[k]; !ok || ov != v {
+			upsert[k] = v
+		}
+	}
+	var remove []string
+	for k := range oldTags {
+		if _, ok := newTags[k]; !ok {
+			remove = append(remove, k)
+		}
+	}
+	sort.Strings(remove)
+	return upsert, remove
+}
+
+// listTags returns all tags set on the given WorkMail resource ARN.
+func listTags(ctx context.Context, client *workmail.Client, arn string) (map[string]string, error) {
+	out, err := client.ListTagsForResource(ctx, &workmail.ListTagsForResourceInput{
+		ResourceARN: aws.String(arn),
+	})
+	if err != nil {
+		return nil, err
+	}
+	tags := make(map[string]string, len(out.Tags))
+	for _, t := range out.Tags {
+		if t.Key != nil {
+			tags[*t.Key] = aws.ToString(t.Value)
+		}
+	}
+	return tags, nil
+}
+
+// updateTags reconciles the tags on the given WorkMail resource ARN from
+// oldTags to newTags using UntagResource and TagResource.
+func updateTags(ctx context.Context, client *workmail.Client, arn string, oldTags, newTags map[string]string) error {
+	upsert, remove := diffTags(oldTags, newTags)
+
+	if len(remove) > 0 {
+		_, err := client.UntagResource(ctx, &workmail.UntagResourceInput{
+			ResourceARN: aws.String(arn),
+			TagKeys:     remove,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(upsert) > 0 {
+		keys := make([]string, 0, len(upsert))
+		for k := range upsert {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		tags := make([]wmtypes.Tag, 0, len(keys))
+		for _, k := range keys {
+			tags = append(tags, wmtypes.Tag{
+				Key:   aws.String(k),
+				Value: aws.String(upsert[k]),
+			})
+		}
+		_, err := client.TagResource(ctx, &workmail.TagResourceInput{
+			ResourceARN: aws.String(arn),
+			Tags:        tags,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package awsworkmail
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergeTags(t *testing.T) {
+	got := mergeTags(
+		map[string]string{"Owner": "platform", "CostCenter": "100"},
+		map[string]string{"CostCenter": "200", "Name": "mail"},
+	)
+	want := map[string]string{"Owner": "platform", "CostCenter": "200", "Name": "mail"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("mergeTags() = %v, want %v", got, want)
+	}
+}
+
+func TestResourceTags(t *testing.T) {
+	remote := map[string]string{"Owner": "platform", "CostCenter": "200", "Manual": "yes", "Env": "prod"}
+	configured := map[string]string{"CostCenter": "200", "Env": "prod"}
+	defaultTags := map[string]string{"Owner": "platform", "Env": "prod"}
+
+	got := resourceTags(remote, configured, defaultTags)
+	want := map[string]string{"CostCenter": "200", "Manual": "yes", "Env": "prod"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("resourceTags() = %v, want %v", got, want)
+	}
+}
+
+func TestDiffTags(t *testing.T) {
+	upsert, remove := diffTags(
+		map[string]string{"a": "1", "b": "2", "c": "3"},
+		map[string]string{"a": "1", "b": "20", "d": "4"},
+	)
+	if want := map[string]string{"b": "20", "d": "4"}; !reflect.DeepEqual(upsert, want) {
+		t.Errorf("diffTags() upsert = %v, want %v", upsert, want)
+	}
+	if want := []string{"c"}; !reflect.DeepEqual(remove, want) {
+		t.Errorf("diffTags() remove = %v, want %v", remove, want)
+	}
+}
@@ -0,0 +1,115 @@
+package awsworkmail
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/workmail"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// timeStringValue renders an optional API timestamp as RFC 3339.
+func timeStringValue(t *time.Time) types.String {
+	if t == nil {
+		return types.StringNull()
+	}
+	return types.StringValue(t.UTC().Format(time.RFC3339))
+}
+
+// getMailboxDetails returns the mailbox quota and size of the user, in MB.
+// Both are null if the user has no mailbox, for example while it is disabled.
+func getMailboxDetails(ctx context.Context, client *workmail.Client, organizationID, userID string) (types.Int64, types.Float64, error) {
+	out, err := client.GetMailboxDetails(ctx, &workmail.GetMailboxDetailsInput{
+		OrganizationId: aws.String(organizationID),
+		UserId:         aws.String(userID),
+	})
+	if err != nil {
+		if isEntityStateException(err) || isEntityNotFound(err) {
+			return types.Int64Null(), types.Float64Null(), nil
+		}
+		return types.Int64Null(), types.Float64Null(), err
+	}
+
+	quota := types.Int64Null()
+	if out.MailboxQuota != nil {
+		quota = types.Int64Value(int64(*out.MailboxQuota))
+	}
+	return quota, types.Float64Value(out.MailboxSize), nil
+}
+
+// updateMailboxQuota applies mailbox_quota_mb. The user must be enabled.
+func updateMailboxQuota(ctx context.Context, client *workmail.Client, data *userResourceModel) error {
+	_, err := client.UpdateMailboxQuota(ctx, &workmail.UpdateMailboxQuotaInput{
+		OrganizationId: aws.String(data.OrganizationID.ValueString()),
+		UserId:         aws.String(data.ID.ValueString()),
+		MailboxQuota:   aws.Int32(int32(data.MailboxQuotaMB.ValueInt64())),
+	})
+	return err
+}
+
+// setUserLifecycleDetails copies the lifecycle timestamps of DescribeUser into
+// the resource model.
+func setUserLifecycleDetails(data *userResourceModel, out *workmail.DescribeUserOutput) {
+	data.EnabledDate = timeStringValue(out.EnabledDate)
+	data.DisabledDate = timeStringValue(out.DisabledDate)
+	data.MailboxProvisionedDate = timeStringValue(out.MailboxProvisionedDate)
+	data.MailboxDeprovisionedDate = timeStringValue(out.MailboxDeprovisionedDate)
+}
+
+// refreshUserDetails reads the computed lifecycle and mailbox attributes that
+// are unknown in the plan after a create or update. Known values were carried
+// over from state and are left for the next refresh to update, so that apply
+// returns what was planned.
+func refreshUserDetails(ctx context.Context, client *workmail.Client, data *userResourceModel) error {
+	out, err := client.DescribeUser(ctx, &workmail.DescribeUserInput{
+		OrganizationId: aws.String(data.OrganizationID.ValueString()),
+		UserId:         aws.String(data.ID.ValueString()),
+	})
+	if err != nil {
+		return err
+	}
+	var refreshed userResourceModel
+	setUserLifecycleDetails(&refreshed, out)
+	refreshed.MailboxQuotaMB, refreshed.MailboxSizeMB, err = getMailboxDetails(ctx, client, data.OrganizationID.ValueString(), data.ID.ValueString())
+	if err != nil {
+		return err
+	}
+
+	for _, v := range []struct{ dst, src *types.String }{
+		{&data.EnabledDate, &refreshed.EnabledDate},
+		{&data.DisabledDate, &refreshed.DisabledDate},
+		{&data.MailboxProvisionedDate, &refreshed.MailboxProvisionedDate},
+		{&data.MailboxDeprovisionedDate, &refreshed.MailboxDeprovisionedDate},
+	} {
+		if v.dst.IsUnknown() {
+			*v.dst = *v.src
+		}
+	}
+	if data.MailboxQuotaMB.IsUnknown() {
+		data.MailboxQuotaMB = refreshed.MailboxQuotaMB
+	}
+	if data.MailboxSizeMB.IsUnknown() {
+		data.MailboxSizeMB = refreshed.MailboxSizeMB
+	}
+	return nil
+}
+
+// clearUnknownUserDetails nulls computed attributes that could not be read,
+// so that a partially created user can still be saved to state.
+func clearUnknownUserDetails(data *userResourceModel) {
+	for _, v := range []*types.String{&data.EnabledDate, &data.DisabledDate, &data.MailboxProvisionedDate, &data.MailboxDeprovisionedDate} {
+		if v.IsUnknown() {
+			*v = types.StringNull()
+		}
+	}
+	if data.MailboxQuotaMB.IsUnknown() {
+		data.MailboxQuotaMB = types.Int64Null()
+	}
+	if data.Aliases.IsUnknown() {
+		data.Aliases = types.SetNull(types.StringType)
+	}
+	if data.MailboxSizeMB.IsUnknown() {
+		data.MailboxSizeMB = types.Float64Null()
+	}
+}